@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Activity is an event with its payload decoded and rendered, as handed to
+// every output format.
+type Activity struct {
+	ID        string
+	Type      string
+	Action    string
+	Repo      string
+	Actor     string
+	CreatedAt time.Time
+	// Message is the sentence rendered by the parse*Event functions, or the
+	// fallback line when the event couldn't be parsed.
+	Message string
+	// Payload is the payload decoded into the struct of its event type, nil
+	// for unsupported event types.
+	Payload any
+	// Err is set when the event couldn't be parsed.
+	Err error
+	// Raw is the event as returned by the API.
+	Raw json.RawMessage
+}
+
+// decodePayload decodes payload into the struct matching eventType. It
+// returns nil without error for unsupported event types.
+func decodePayload(eventType string, payload json.RawMessage) (any, error) {
+	var v any
+	switch eventType {
+	case "CreateEvent":
+		v = &CreateEvent{}
+	case "DeleteEvent":
+		v = &DeleteEvent{}
+	case "IssuesEvent":
+		v = &IssuesEvent{}
+	case "PullRequestEvent":
+		v = &PullRequestEvent{}
+	case "PushEvent":
+		v = &PushEvent{}
+	case "ReleaseEvent":
+		v = &ReleaseEvent{}
+	case "ForkEvent":
+		v = &ForkEvent{}
+	case "WatchEvent":
+		v = &WatchEvent{}
+	case "IssueCommentEvent":
+		v = &IssueCommentEvent{}
+	case "CommitCommentEvent":
+		v = &CommitCommentEvent{}
+	case "GollumEvent":
+		v = &GollumEvent{}
+	case "MemberEvent":
+		v = &MemberEvent{}
+	case "PullRequestReviewEvent":
+		v = &PullRequestReviewEvent{}
+	case "PullRequestReviewCommentEvent":
+		v = &PullRequestReviewCommentEvent{}
+	case "PublicEvent":
+		return &PublicEvent{}, nil
+	default:
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(payload, v); err != nil {
+		return nil, &DecodeError{What: eventType + " payload", Err: err}
+	}
+	return v, nil
+}
+
+// eventAction returns the action of a payload, empty for event types
+// without actions.
+func eventAction(payload json.RawMessage) string {
+	var action struct {
+		Action string `json:"action"`
+	}
+	if json.Unmarshal(payload, &action) != nil {
+		return ""
+	}
+	return action.Action
+}
+
+// newActivity decodes and renders event. Parse failures are reported in Err
+// and rendered with fallbackEvent, as are event types that aren't supported
+// but without an error.
+func newActivity(event RawEvent) *Activity {
+	a := &Activity{
+		ID:        event.ID,
+		Type:      event.Type,
+		Action:    eventAction(event.Payload),
+		Repo:      event.Repo.Name,
+		Actor:     event.Actor.Login,
+		CreatedAt: event.CreatedAt,
+		Raw:       event.Raw,
+	}
+
+	a.Payload, a.Err = decodePayload(event.Type, event.Payload)
+	if a.Err == nil && a.Payload == nil {
+		a.Message = fallbackEvent(event.Type, event.Repo.Name)
+		return a
+	}
+	if a.Err == nil {
+		a.Message, a.Err = parseEvent(event.Type, event.Payload, event.Repo.Name)
+	}
+	if a.Err != nil {
+		a.Message = fallbackEvent(event.Type, event.Repo.Name)
+	}
+	return a
+}
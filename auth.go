@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const githubHost = "github.com"
+
+// resolveToken looks for a personal access token in, by order of precedence,
+// the --token flag, the --token-file flag, the GITHUB_TOKEN and GH_TOKEN
+// environment variables, the gh CLI hosts.yml and the netrc file. An empty
+// token without error means the requests are sent anonymously.
+func resolveToken(flagToken, tokenFile string, getenv func(string) string) (string, error) {
+	if flagToken != "" {
+		return flagToken, nil
+	}
+
+	if tokenFile != "" {
+		b, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return "", fmt.Errorf("unable to read token file: %w", err)
+		}
+		token := strings.TrimSpace(string(b))
+		if token == "" {
+			return "", fmt.Errorf("token file %s is empty", tokenFile)
+		}
+		return token, nil
+	}
+
+	for _, name := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if token := strings.TrimSpace(getenv(name)); token != "" {
+			return token, nil
+		}
+	}
+
+	token, err := ghHostsToken(ghHostsPath(getenv))
+	if err != nil || token != "" {
+		return token, err
+	}
+
+	return netrcToken(netrcPath(getenv))
+}
+
+// ghHostsPath returns the location of the gh CLI hosts.yml.
+func ghHostsPath(getenv func(string) string) string {
+	if dir := getenv("GH_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, "hosts.yml")
+	}
+	if dir := getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh", "hosts.yml")
+	}
+	return filepath.Join(getenv("HOME"), ".config", "gh", "hosts.yml")
+}
+
+// netrcPath returns the location of the netrc file.
+func netrcPath(getenv func(string) string) string {
+	if p := getenv("NETRC"); p != "" {
+		return p
+	}
+	return filepath.Join(getenv("HOME"), ".netrc")
+}
+
+// ghHostsToken returns the github.com oauth_token stored by the gh CLI. A
+// missing file is not an error.
+func ghHostsToken(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("unable to read gh hosts file: %w", err)
+	}
+
+	var hosts map[string]struct {
+		OauthToken string `yaml:"oauth_token"`
+	}
+	if err := yaml.Unmarshal(b, &hosts); err != nil {
+		return "", fmt.Errorf("unable to parse gh hosts file %s", path)
+	}
+
+	return hosts[githubHost].OauthToken, nil
+}
+
+// netrcToken returns the password of the api.github.com or github.com
+// machine entry in a netrc file. A missing file is not an error.
+func netrcToken(path string) (string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("unable to read netrc file: %w", err)
+	}
+	defer f.Close()
+
+	tokens := make(map[string]string)
+	var machine string
+	scanner := bufio.NewScanner(f)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		switch scanner.Text() {
+		case "machine":
+			if scanner.Scan() {
+				machine = scanner.Text()
+			}
+		case "default":
+			machine = ""
+		case "password":
+			if scanner.Scan() && machine != "" {
+				tokens[machine] = scanner.Text()
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("unable to read netrc file: %w", err)
+	}
+
+	if token, ok := tokens["api."+githubHost]; ok {
+		return token, nil
+	}
+	return tokens[githubHost], nil
+}
+
+// redact replaces every occurrence of token in s.
+func redact(s, token string) string {
+	if token == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, token, "[REDACTED]")
+}
+
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// redactError hides token from the message of err while keeping it
+// inspectable with errors.Is and errors.As.
+func redactError(err error, token string) error {
+	if err == nil || token == "" || !strings.Contains(err.Error(), token) {
+		return err
+	}
+	return &redactedError{msg: redact(err.Error(), token), err: err}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.Nil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestResolveToken(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+
+	t.Run("Successfully validates token flag takes precedence", func(t *testing.T) {
+		token, err := resolveToken("flag-token", "", env(map[string]string{"GITHUB_TOKEN": "env-token"}))
+		require.Nil(t, err)
+		require.Equal(t, "flag-token", token)
+	})
+
+	t.Run("Successfully validates token file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "token")
+		writeFile(t, path, "file-token\n")
+		token, err := resolveToken("", path, env(map[string]string{"GITHUB_TOKEN": "env-token"}))
+		require.Nil(t, err)
+		require.Equal(t, "file-token", token)
+	})
+
+	t.Run("Successfully validates GITHUB_TOKEN before GH_TOKEN", func(t *testing.T) {
+		token, err := resolveToken("", "", env(map[string]string{"GITHUB_TOKEN": "github-token", "GH_TOKEN": "gh-token"}))
+		require.Nil(t, err)
+		require.Equal(t, "github-token", token)
+	})
+
+	t.Run("Successfully validates GH_TOKEN", func(t *testing.T) {
+		token, err := resolveToken("", "", env(map[string]string{"GH_TOKEN": "gh-token", "HOME": t.TempDir()}))
+		require.Nil(t, err)
+		require.Equal(t, "gh-token", token)
+	})
+
+	t.Run("Successfully validates gh hosts file", func(t *testing.T) {
+		home := t.TempDir()
+		writeFile(t, filepath.Join(home, ".config", "gh", "hosts.yml"), `github.com:
+    user: devUser
+    oauth_token: gho_hosts
+    git_protocol: https
+`)
+		writeFile(t, filepath.Join(home, ".netrc"), "machine api.github.com login devUser password netrc-token\n")
+		token, err := resolveToken("", "", env(map[string]string{"HOME": home}))
+		require.Nil(t, err)
+		require.Equal(t, "gho_hosts", token)
+	})
+
+	t.Run("Successfully validates netrc file", func(t *testing.T) {
+		home := t.TempDir()
+		writeFile(t, filepath.Join(home, ".netrc"), `machine example.com login other password other-token
+machine github.com
+    login devUser
+    password netrc-token
+`)
+		token, err := resolveToken("", "", env(map[string]string{"HOME": home}))
+		require.Nil(t, err)
+		require.Equal(t, "netrc-token", token)
+	})
+
+	t.Run("Successfully validates anonymous access without token", func(t *testing.T) {
+		token, err := resolveToken("", "", env(map[string]string{"HOME": t.TempDir()}))
+		require.Nil(t, err)
+		require.Empty(t, token)
+	})
+
+	t.Run("Successfully validates error for missing token file", func(t *testing.T) {
+		token, err := resolveToken("", filepath.Join(t.TempDir(), "missing"), env(nil))
+		require.ErrorIs(t, err, os.ErrNotExist)
+		require.Empty(t, token)
+	})
+}
+
+func TestRedactError(t *testing.T) {
+	t.Run("Successfully validates token is redacted", func(t *testing.T) {
+		inner := fmt.Errorf("request with secret-token failed")
+		err := redactError(inner, "secret-token")
+		require.EqualError(t, err, "request with [REDACTED] failed")
+		require.True(t, errors.Is(err, inner))
+	})
+
+	t.Run("Successfully validates error without token is unchanged", func(t *testing.T) {
+		inner := fmt.Errorf("request failed")
+		require.Equal(t, inner, redactError(inner, "secret-token"))
+	})
+}
+
+func TestClientAuthentication(t *testing.T) {
+	t.Run("Successfully validates Authorization header and authenticated user", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "Bearer secret-token", r.Header.Get("Authorization"))
+			fmt.Fprint(w, `{"login": "devUser"}`)
+		}))
+		defer srv.Close()
+
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL, token: "secret-token"}
+		login, err := c.authenticatedUser()
+		require.Nil(t, err)
+		require.Equal(t, "devUser", login)
+	})
+
+	t.Run("Successfully validates private events URL", func(t *testing.T) {
+		c := &client{baseURL: apiBaseURL}
+		require.Equal(t, "https://api.github.com/users/devUser/events?per_page=100", c.eventsURL("devUser", true))
+		require.Equal(t, "https://api.github.com/users/devUser/events/public?per_page=100", c.eventsURL("devUser", false))
+	})
+}
+
+func TestRunAuthentication(t *testing.T) {
+	t.Run("Successfully validates public events when the token can't read the user", func(t *testing.T) {
+		var paths []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.URL.Path)
+			if r.URL.Path == "/user" {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"message": "Resource not accessible by integration"}`)
+				return
+			}
+			fmt.Fprint(w, "["+pushEventJSON+"]")
+		}))
+		defer srv.Close()
+		setupRun(t, srv)
+		t.Setenv("GITHUB_TOKEN", "secret-token")
+
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--time", "iso", "--tz", "UTC", "devUser"}, &stdout, &stderr)
+		require.Nil(t, err)
+		require.Equal(t, []string{"/user", "/users/devUser/events/public"}, paths)
+		require.Equal(t, "2024-11-28T14:05:00Z  Pushed 2 commits to main in devUser/awesome-project\n", stdout.String())
+	})
+}
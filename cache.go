@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const cacheEntryExt = ".json"
+
+// httpCache stores response bodies on disk along with their validators so
+// requests can be made conditional. A 304 Not Modified response doesn't
+// count against the rate limit.
+type httpCache struct {
+	dir string
+}
+
+type cacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	StoredAt     time.Time `json:"stored_at"`
+	Body         []byte    `json:"body"`
+}
+
+type cacheStats struct {
+	Dir     string
+	Entries int
+	Size    int64
+	Oldest  time.Time
+	Newest  time.Time
+}
+
+// cacheDir returns the cache directory, under $XDG_CACHE_HOME when set.
+func cacheDir(getenv func(string) string) string {
+	if dir := getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "github-activity")
+	}
+	return filepath.Join(getenv("HOME"), ".cache", "github-activity")
+}
+
+// key identifies the cached response of u for token, so responses that
+// include private events are never served to another token.
+func (hc *httpCache) key(u, token string) string {
+	sum := sha256.Sum256([]byte(token + "\n" + u))
+	return hex.EncodeToString(sum[:])
+}
+
+func (hc *httpCache) path(key string) string {
+	return filepath.Join(hc.dir, key+cacheEntryExt)
+}
+
+// load returns the entry stored under key, or nil when there is none.
+func (hc *httpCache) load(key string) (*cacheEntry, error) {
+	b, err := os.ReadFile(hc.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return nil, &DecodeError{What: "cache entry", Err: err}
+	}
+	return &entry, nil
+}
+
+// store writes entry under key, replacing any previous entry atomically.
+func (hc *httpCache) store(key string, entry *cacheEntry) error {
+	if err := os.MkdirAll(hc.dir, 0o700); err != nil {
+		return err
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(hc.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), hc.path(key))
+}
+
+// entries returns the paths of every stored entry.
+func (hc *httpCache) entries() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(hc.dir, "*"+cacheEntryExt))
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// clear removes every stored entry and returns how many were removed.
+func (hc *httpCache) clear() (int, error) {
+	paths, err := hc.entries()
+	if err != nil {
+		return 0, err
+	}
+	for i, p := range paths {
+		if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return i, err
+		}
+	}
+	return len(paths), nil
+}
+
+func (hc *httpCache) stats() (cacheStats, error) {
+	stats := cacheStats{Dir: hc.dir}
+	paths, err := hc.entries()
+	if err != nil {
+		return stats, err
+	}
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+		stats.Entries++
+		stats.Size += info.Size()
+		if stats.Oldest.IsZero() || info.ModTime().Before(stats.Oldest) {
+			stats.Oldest = info.ModTime()
+		}
+		if info.ModTime().After(stats.Newest) {
+			stats.Newest = info.ModTime()
+		}
+	}
+	return stats, nil
+}
+
+// setConditionalHeaders makes req conditional on entry still being current.
+func setConditionalHeaders(req *http.Request, entry *cacheEntry) {
+	if entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.LastModified)
+	}
+}
+
+// revalidate serves the cached body of entry when resp is a 304 Not
+// Modified, and stores the body of successful responses that carry a
+// validator. resp is returned unchanged otherwise.
+func (hc *httpCache) revalidate(key string, entry *cacheEntry, resp *http.Response) (*http.Response, error) {
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		resp.Body.Close()
+		resp.StatusCode = http.StatusOK
+		resp.Status = "200 OK (cached)"
+		resp.Body = io.NopCloser(bytes.NewReader(entry.Body))
+		return resp, nil
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return resp, &NetworkError{URL: resp.Request.URL.String(), Err: err}
+	}
+
+	return resp, hc.store(key, &cacheEntry{
+		URL:          resp.Request.URL.String(),
+		ETag:         etag,
+		LastModified: lastModified,
+		StoredAt:     time.Now().UTC(),
+		Body:         body,
+	})
+}
+
+// runCache implements the cache subcommand.
+func runCache(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("github-activity cache", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: github-activity cache clear|stats")
+		fs.PrintDefaults()
+	}
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return &UsageError{Message: "expected exactly one cache command"}
+	}
+
+	hc := &httpCache{dir: cacheDir(os.Getenv)}
+	switch fs.Arg(0) {
+	case "clear":
+		n, err := hc.clear()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Removed %d cached responses from %s\n", n, hc.dir)
+	case "stats":
+		stats, err := hc.stats()
+		if err != nil {
+			return err
+		}
+		printCacheStats(stdout, stats)
+	default:
+		fs.Usage()
+		return &UsageError{Message: fmt.Sprintf("unknown cache command %q", fs.Arg(0))}
+	}
+	return nil
+}
+
+func printCacheStats(w io.Writer, stats cacheStats) {
+	fmt.Fprintf(w, "directory: %s\n", stats.Dir)
+	fmt.Fprintf(w, "entries: %d\n", stats.Entries)
+	fmt.Fprintf(w, "size: %d bytes\n", stats.Size)
+	if stats.Entries > 0 {
+		fmt.Fprintf(w, "oldest: %s\n", stats.Oldest.Local().Format(time.DateTime))
+		fmt.Fprintf(w, "newest: %s\n", stats.Newest.Local().Format(time.DateTime))
+	}
+}
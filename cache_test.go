@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheDir(t *testing.T) {
+	t.Run("Successfully validates XDG_CACHE_HOME", func(t *testing.T) {
+		dir := cacheDir(func(name string) string {
+			return map[string]string{"XDG_CACHE_HOME": "/tmp/cache", "HOME": "/home/devUser"}[name]
+		})
+		require.Equal(t, filepath.Join("/tmp/cache", "github-activity"), dir)
+	})
+
+	t.Run("Successfully validates default cache directory", func(t *testing.T) {
+		dir := cacheDir(func(name string) string {
+			return map[string]string{"HOME": "/home/devUser"}[name]
+		})
+		require.Equal(t, filepath.Join("/home/devUser", ".cache", "github-activity"), dir)
+	})
+}
+
+func TestClientCache(t *testing.T) {
+	// newCachingServer answers with an ETag and honours If-None-Match.
+	newCachingServer := func(t *testing.T, requests *[]string) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*requests = append(*requests, r.Header.Get("If-None-Match"))
+			w.Header().Set("ETag", `"abc123"`)
+			if r.Header.Get("If-None-Match") == `"abc123"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			fmt.Fprint(w, `[{"type": "PushEvent"}]`)
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+
+	read := func(t *testing.T, c *client, u string) string {
+		resp, err := c.get(u)
+		require.Nil(t, err)
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		require.Nil(t, err)
+		return string(b)
+	}
+
+	t.Run("Successfully validates cached body served on not modified", func(t *testing.T) {
+		var requests []string
+		srv := newCachingServer(t, &requests)
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL, cache: &httpCache{dir: t.TempDir()}}
+
+		require.Equal(t, `[{"type": "PushEvent"}]`, read(t, c, srv.URL))
+		require.Equal(t, `[{"type": "PushEvent"}]`, read(t, c, srv.URL))
+		require.Equal(t, []string{"", `"abc123"`}, requests)
+	})
+
+	t.Run("Successfully validates cache entries are per token", func(t *testing.T) {
+		var requests []string
+		srv := newCachingServer(t, &requests)
+		hc := &httpCache{dir: t.TempDir()}
+
+		read(t, &client{httpClient: srv.Client(), baseURL: srv.URL, cache: hc, token: "first"}, srv.URL)
+		read(t, &client{httpClient: srv.Client(), baseURL: srv.URL, cache: hc, token: "second"}, srv.URL)
+		require.Equal(t, []string{"", ""}, requests)
+	})
+
+	t.Run("Successfully validates stats and clear", func(t *testing.T) {
+		var requests []string
+		srv := newCachingServer(t, &requests)
+		hc := &httpCache{dir: t.TempDir()}
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL, cache: hc}
+		read(t, c, srv.URL+"/one")
+		read(t, c, srv.URL+"/two")
+
+		stats, err := hc.stats()
+		require.Nil(t, err)
+		require.Equal(t, 2, stats.Entries)
+		require.Greater(t, stats.Size, int64(0))
+
+		n, err := hc.clear()
+		require.Nil(t, err)
+		require.Equal(t, 2, n)
+
+		stats, err = hc.stats()
+		require.Nil(t, err)
+		require.Equal(t, 0, stats.Entries)
+	})
+}
+
+func TestRunCache(t *testing.T) {
+	t.Run("Successfully validates cache stats subcommand", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("XDG_CACHE_HOME", dir)
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"cache", "stats"}, &stdout, &stderr)
+		require.Nil(t, err)
+		require.Equal(t, fmt.Sprintf("directory: %s\nentries: 0\nsize: 0 bytes\n", filepath.Join(dir, "github-activity")), stdout.String())
+	})
+
+	t.Run("Successfully validates error for unknown cache command", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"cache", "purge"}, &stdout, &stderr)
+		require.EqualError(t, err, `unknown cache command "purge"`)
+		require.Equal(t, exitUsage, exitCode(err))
+	})
+}
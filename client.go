@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	apiBaseURL      = "https://api.github.com"
+	eventsPerPage   = 100
+	defaultMaxPages = 3
+)
+
+type client struct {
+	httpClient *http.Client
+	baseURL    string
+	token      string
+	debug      io.Writer
+	warnings   io.Writer
+
+	rateLimit          rateLimit
+	warnedLowRateLimit bool
+	waitOnRateLimit    bool
+	retry              retryPolicy
+	sleep              func(time.Duration)
+	cache              *httpCache
+}
+
+func newClient(token string) *client {
+	return &client{
+		httpClient: &http.Client{
+			// Redirects are reported by checkResponse rather than followed.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		baseURL: apiBaseURL,
+		token:   token,
+		retry:   defaultRetryPolicy,
+		sleep:   time.Sleep,
+	}
+}
+
+// debugf writes a debug log line when debugging is enabled, with the token
+// redacted.
+func (c *client) debugf(format string, args ...any) {
+	if c.debug == nil {
+		return
+	}
+	fmt.Fprintln(c.debug, "debug: "+redact(fmt.Sprintf(format, args...), c.token))
+}
+
+// get sends a GET request and returns the response once checkResponse
+// accepts it. Rate limited requests are retried after waiting for the limit
+// to reset when waitOnRateLimit is set, and transient failures are retried
+// with backoff according to the retry policy. Every attempt times out at the
+// deadline of the retry policy, which doesn't count rate limit waits.
+func (c *client) get(u string) (*http.Response, error) {
+	start := time.Now()
+	var attempts []attempt
+	waits := 0
+	var waited time.Duration
+	for {
+		resp, err := c.do(u, c.retry.Deadline-(time.Since(start)-waited))
+		if err == nil {
+			c.trackRateLimit(resp.Header)
+			err = checkResponse(resp)
+			if err == nil {
+				return resp, nil
+			}
+			resp.Body.Close()
+		}
+		attempts = append(attempts, attempt{Err: err, Elapsed: time.Since(start)})
+
+		var statusErr *StatusError
+		if c.waitOnRateLimit && waits < maxRateLimitWaits && errors.As(err, &statusErr) {
+			if wait, ok := rateLimitWait(statusErr, time.Now()); ok {
+				waits++
+				if c.warnings != nil {
+					fmt.Fprintf(c.warnings, "warning: %s, waiting %s\n", statusErr.Kind, wait.Round(time.Second))
+				}
+				sleptAt := time.Now()
+				c.sleep(wait)
+				waited += time.Since(sleptAt)
+				continue
+			}
+		}
+
+		retries := len(attempts) - waits
+		if !isRetryable(http.MethodGet, err) || retries >= c.retry.MaxAttempts {
+			return nil, giveUp(attempts)
+		}
+		delay := c.retry.backoff(retries)
+		if time.Since(start)-waited+delay > c.retry.Deadline {
+			return nil, giveUp(attempts)
+		}
+		c.debugf("retrying %s in %s: %s", u, delay.Round(time.Millisecond), err)
+		c.sleep(delay)
+	}
+}
+
+// giveUp returns the error of the last attempt, with the attempt history
+// when the request was attempted more than once.
+func giveUp(attempts []attempt) error {
+	if len(attempts) == 1 {
+		return attempts[0].Err
+	}
+	return &RetryError{Attempts: attempts}
+}
+
+// do sends a single GET request, authenticated when a token is set. The
+// request, reading the body included, times out after timeout when the
+// retry policy has a deadline.
+func (c *client) do(u string, timeout time.Duration) (*http.Response, error) {
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if c.retry.Deadline > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		cancel()
+		return nil, redactError(err, c.token)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	var key string
+	var entry *cacheEntry
+	if c.cache != nil {
+		key = c.cache.key(u, c.token)
+		if entry, err = c.cache.load(key); err != nil {
+			c.debugf("ignoring cache entry for %s: %s", u, err)
+		} else if entry != nil {
+			setConditionalHeaders(req, entry)
+		}
+	}
+
+	c.debugf("GET %s", u)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, redactError(&NetworkError{URL: u, Err: err}, c.token)
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	if c.cache != nil {
+		resp, err = c.cache.revalidate(key, entry, resp)
+		if err != nil {
+			c.debugf("unable to cache %s: %s", u, err)
+		}
+	}
+	c.debugf("%s %s", resp.Status, u)
+	return resp, nil
+}
+
+// cancelOnClose releases the context of a request once its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// authenticatedUser returns the login the token belongs to.
+func (c *client) authenticatedUser() (string, error) {
+	resp, err := c.get(c.baseURL + "/user")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return "", &DecodeError{What: "authenticated user", Err: err}
+	}
+	return user.Login, nil
+}
+
+// eventsURL returns the first page of events for username, including private
+// events when private is set.
+func (c *client) eventsURL(username string, private bool) string {
+	u := fmt.Sprintf("%s/users/%s/events", c.baseURL, url.PathEscape(username))
+	if !private {
+		u += "/public"
+	}
+	return fmt.Sprintf("%s?per_page=%d", u, eventsPerPage)
+}
+
+// parseLinkHeader returns the URLs of a Link response header keyed by their rel value.
+func parseLinkHeader(header string) map[string]string {
+	links := make(map[string]string)
+	for _, part := range strings.Split(header, ",") {
+		sections := strings.Split(part, ";")
+		if len(sections) < 2 {
+			continue
+		}
+
+		u := strings.TrimSpace(sections[0])
+		if !strings.HasPrefix(u, "<") || !strings.HasSuffix(u, ">") {
+			continue
+		}
+		u = u[1 : len(u)-1]
+
+		for _, param := range sections[1:] {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && key == "rel" {
+				for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+					links[rel] = u
+				}
+			}
+		}
+	}
+	return links
+}
+
+// errStopPagination is returned by the handle function of fetchEvents to
+// stop fetching pages without failing.
+var errStopPagination = errors.New("stop pagination")
+
+// fetchEvents follows the rel="next" links starting at u and calls handle with
+// every page as soon as it is decoded. It stops after maxPages pages or when
+// handle returns errStopPagination.
+func (c *client) fetchEvents(u string, maxPages int, handle func(Event) error) error {
+	for page := 0; u != "" && page < maxPages; page++ {
+		resp, err := c.get(u)
+		if err != nil {
+			return err
+		}
+
+		var events Event
+		err = json.NewDecoder(resp.Body).Decode(&events)
+		resp.Body.Close()
+		if err != nil {
+			return &DecodeError{What: "events", Err: err}
+		}
+
+		if err := handle(events); errors.Is(err, errStopPagination) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		u = parseLinkHeader(resp.Header.Get("Link"))["next"]
+	}
+	return nil
+}
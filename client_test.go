@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer serves pages of events, linking each page to the next one.
+func newTestServer(t *testing.T, pages []string) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+		if page < len(pages) {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=%d>; rel="next", <%s%s?page=%d>; rel="last"`,
+				srv.URL, r.URL.Path, page+1, srv.URL, r.URL.Path, len(pages)))
+		}
+		fmt.Fprint(w, pages[page-1])
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestParseLinkHeader(t *testing.T) {
+	t.Run("Successfully validates Link header with next and last", func(t *testing.T) {
+		header := `<https://api.github.com/user/1/events?page=2>; rel="next", <https://api.github.com/user/1/events?page=3>; rel="last"`
+		links := parseLinkHeader(header)
+		require.Equal(t, "https://api.github.com/user/1/events?page=2", links["next"])
+		require.Equal(t, "https://api.github.com/user/1/events?page=3", links["last"])
+	})
+
+	t.Run("Successfully validates empty Link header", func(t *testing.T) {
+		links := parseLinkHeader("")
+		require.Empty(t, links)
+	})
+
+	t.Run("Successfully validates malformed Link header", func(t *testing.T) {
+		links := parseLinkHeader(`https://api.github.com/user/1/events?page=2; rel="next"`)
+		require.Empty(t, links["next"])
+	})
+}
+
+func TestFetchEvents(t *testing.T) {
+	pages := []string{
+		`[{"type": "PushEvent", "repo": {"name": "devUser/one"}, "payload": {"size": 1}},
+		  {"type": "PushEvent", "repo": {"name": "devUser/two"}, "payload": {"size": 2}}]`,
+		`[{"type": "PushEvent", "repo": {"name": "devUser/three"}, "payload": {"size": 3}}]`,
+		`[{"type": "PushEvent", "repo": {"name": "devUser/four"}, "payload": {"size": 4}}]`,
+	}
+
+	collect := func(names *[]string) func(Event) error {
+		return func(events Event) error {
+			for _, event := range events {
+				*names = append(*names, event.Repo.Name)
+			}
+			return nil
+		}
+	}
+
+	t.Run("Successfully validates following every next page", func(t *testing.T) {
+		srv := newTestServer(t, pages)
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL}
+		var names []string
+		err := c.fetchEvents(c.eventsURL("devUser", false), defaultMaxPages, collect(&names))
+		require.Nil(t, err)
+		require.Equal(t, []string{"devUser/one", "devUser/two", "devUser/three", "devUser/four"}, names)
+	})
+
+	t.Run("Successfully validates max pages", func(t *testing.T) {
+		srv := newTestServer(t, pages)
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL}
+		var names []string
+		err := c.fetchEvents(c.eventsURL("devUser", false), 2, collect(&names))
+		require.Nil(t, err)
+		require.Equal(t, []string{"devUser/one", "devUser/two", "devUser/three"}, names)
+	})
+
+	t.Run("Successfully validates stopping pagination", func(t *testing.T) {
+		srv := newTestServer(t, pages)
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL}
+		var names []string
+		err := c.fetchEvents(c.eventsURL("devUser", false), defaultMaxPages, func(events Event) error {
+			collect(&names)(events)
+			if len(names) >= 3 {
+				return errStopPagination
+			}
+			return nil
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{"devUser/one", "devUser/two", "devUser/three"}, names)
+	})
+
+	t.Run("Successfully validates error for not found user", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		defer srv.Close()
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL}
+		err := c.fetchEvents(c.eventsURL("devUser", false), defaultMaxPages, collect(new([]string)))
+		require.EqualError(t, err, "not found (HTTP 404)")
+	})
+}
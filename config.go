@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// config is the content of the config file.
+type config struct {
+	// Messages overrides the sentences of defaultMessages, keyed by event
+	// type and action such as IssuesEvent.opened.
+	Messages map[string]string `yaml:"messages"`
+}
+
+// configPath returns the default location of the config file.
+func configPath(getenv func(string) string) string {
+	if dir := getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "github-activity", "config.yml")
+	}
+	return filepath.Join(getenv("HOME"), ".config", "github-activity", "config.yml")
+}
+
+// loadConfig reads the config file at path. A missing file is only an error
+// when it was given explicitly.
+func loadConfig(path string, explicit bool) (*config, error) {
+	cfg := &config{}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return cfg, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read config: %w", err)
+	}
+
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("unable to parse config %s: %w", path, err)
+	}
+	return cfg, nil
+}
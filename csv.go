@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvColumns are the CSV and TSV columns, in their default order.
+var csvColumns = []string{"timestamp", "actor", "type", "action", "repo", "number", "title", "url", "size"}
+
+// csvFields extracts the CSV and TSV columns from an activity, using the
+// payload structs decoded for the parse*Event functions.
+func csvFields(a *Activity) map[string]string {
+	fields := map[string]string{
+		"actor":  a.Actor,
+		"type":   a.Type,
+		"action": a.Action,
+		"repo":   a.Repo,
+	}
+	if !a.CreatedAt.IsZero() {
+		fields["timestamp"] = a.CreatedAt.UTC().Format(time.RFC3339)
+	}
+
+	switch p := a.Payload.(type) {
+	case *CreateEvent:
+		fields["title"] = p.Ref
+	case *DeleteEvent:
+		fields["title"] = p.Ref
+	case *IssuesEvent:
+		fields["number"] = strconv.Itoa(p.Issue.Number)
+		fields["title"] = p.Issue.Title
+	case *PullRequestEvent:
+		fields["number"] = strconv.Itoa(p.Number)
+		fields["title"] = p.PullRequest.Title
+		fields["url"] = p.PullRequest.HtmlUrl
+	case *PushEvent:
+		fields["title"] = p.Branch() + p.Tag()
+		if p.Size != nil {
+			fields["size"] = strconv.Itoa(*p.Size)
+		}
+	case *ReleaseEvent:
+		fields["title"] = p.Release.Name
+		fields["url"] = p.Release.Url
+	case *IssueCommentEvent:
+		fields["number"] = strconv.Itoa(p.Issue.Number)
+		fields["title"] = p.Issue.Title
+		fields["url"] = p.Comment.Url
+	case *CommitCommentEvent:
+		fields["title"] = shortSHA(p.Comment.CommitID)
+		fields["url"] = p.Comment.Url
+	case *GollumEvent:
+		if len(p.Pages) > 0 {
+			fields["title"] = p.Pages[0].Title
+			fields["url"] = p.Pages[0].Url
+		}
+	case *MemberEvent:
+		fields["title"] = p.Member.Login
+		fields["url"] = p.Member.Url
+	case *PullRequestReviewEvent:
+		fields["number"] = strconv.Itoa(p.PullRequest.Number)
+		fields["title"] = p.PullRequest.Title
+		fields["url"] = p.Review.Url
+	case *PullRequestReviewCommentEvent:
+		fields["number"] = strconv.Itoa(p.PullRequest.Number)
+		fields["title"] = p.PullRequest.Title
+		fields["url"] = p.Comment.Url
+	case *ForkEvent:
+		fields["title"] = p.Forkee.FullName
+		fields["url"] = p.Forkee.Url
+	}
+	return fields
+}
+
+// csvWriter writes one record per activity under a header line, quoting
+// fields as described by RFC 4180.
+type csvWriter struct {
+	w             *csv.Writer
+	columns       []string
+	headerWritten bool
+}
+
+func newCSVWriter(w io.Writer, comma rune, columns []string) (*csvWriter, error) {
+	if len(columns) == 0 {
+		columns = csvColumns
+	}
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		selected[i] = strings.TrimSpace(column)
+		if !isCSVColumn(selected[i]) {
+			return nil, &UsageError{Message: fmt.Sprintf("unknown column %q, expected one of %s", selected[i], strings.Join(csvColumns, ", "))}
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	// RFC 4180 records end in CRLF, TSV keeps plain line feeds.
+	cw.UseCRLF = comma == ','
+	return &csvWriter{w: cw, columns: selected}, nil
+}
+
+func isCSVColumn(name string) bool {
+	for _, column := range csvColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (cw *csvWriter) writeHeader() error {
+	if cw.headerWritten {
+		return nil
+	}
+	cw.headerWritten = true
+	return cw.w.Write(cw.columns)
+}
+
+func (cw *csvWriter) WriteActivity(a *Activity) error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	fields := csvFields(a)
+	record := make([]string, len(cw.columns))
+	for i, column := range cw.columns {
+		record[i] = fields[column]
+	}
+	return cw.w.Write(record)
+}
+
+func (cw *csvWriter) Close() error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	cw.w.Flush()
+	return cw.w.Error()
+}
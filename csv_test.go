@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVWriter(t *testing.T) {
+	issueEventJSON := `{
+		"id": "2489651047",
+		"type": "IssuesEvent",
+		"actor": {"login": "devUser"},
+		"repo": {"name": "devUser/awesome-project"},
+		"payload": {"action": "opened", "issue": {"number": 7, "title": "Crash when title has \"quotes\", commas"}},
+		"created_at": "2024-11-28T16:00:00Z"
+	}`
+	activities := []*Activity{
+		newActivity(decodeRawEvent(t, pushEventJSON)),
+		newActivity(decodeRawEvent(t, pullRequestEventJSON)),
+		newActivity(decodeRawEvent(t, issueEventJSON)),
+	}
+
+	write := func(t *testing.T, opts outputOptions) string {
+		var buf bytes.Buffer
+		w, err := newOutputWriter(&buf, opts)
+		require.Nil(t, err)
+		for _, a := range activities {
+			require.Nil(t, w.WriteActivity(a))
+		}
+		require.Nil(t, w.Close())
+		return buf.String()
+	}
+
+	t.Run("Successfully validates csv output with quoting", func(t *testing.T) {
+		exp := "timestamp,actor,type,action,repo,number,title,url,size\r\n" +
+			"2024-11-28T14:05:00Z,devUser,PushEvent,,devUser/awesome-project,,main,,2\r\n" +
+			"2024-11-28T15:00:00Z,devUser,PullRequestEvent,opened,devUser/awesome-project,42,Add feature X,https://github.com/devUser/awesome-project/pull/42,\r\n" +
+			"2024-11-28T16:00:00Z,devUser,IssuesEvent,opened,devUser/awesome-project,7,\"Crash when title has \"\"quotes\"\", commas\",,\r\n"
+		require.Equal(t, exp, write(t, outputOptions{Format: "csv"}))
+	})
+
+	t.Run("Successfully validates tsv output with selected columns", func(t *testing.T) {
+		exp := "repo\ttype\tnumber\n" +
+			"devUser/awesome-project\tPushEvent\t\n" +
+			"devUser/awesome-project\tPullRequestEvent\t42\n" +
+			"devUser/awesome-project\tIssuesEvent\t7\n"
+		require.Equal(t, exp, write(t, outputOptions{Format: "tsv", Columns: []string{"repo", " type", "number"}}))
+	})
+
+	t.Run("Successfully validates header without events", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := newOutputWriter(&buf, outputOptions{Format: "csv", Columns: []string{"type", "repo"}})
+		require.Nil(t, err)
+		require.Nil(t, w.Close())
+		require.Equal(t, "type,repo\r\n", buf.String())
+	})
+
+	t.Run("Successfully validates error for unknown column", func(t *testing.T) {
+		_, err := newOutputWriter(&bytes.Buffer{}, outputOptions{Format: "csv", Columns: []string{"repo", "stars"}})
+		require.EqualError(t, err, `unknown column "stars", expected one of timestamp, actor, type, action, repo, number, title, url, size`)
+		require.Equal(t, exitUsage, exitCode(err))
+	})
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Exit codes returned by the CLI, stable so scripts can branch on them.
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitNotFound    = 3
+	exitRateLimited = 4
+	exitPartial     = 5
+)
+
+// UsageError reports invalid command line arguments.
+type UsageError struct {
+	Message string
+}
+
+func (e *UsageError) Error() string {
+	return e.Message
+}
+
+// NetworkError reports a request that failed before a response was received.
+type NetworkError struct {
+	URL string
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("request to %s failed: %s", e.URL, e.Err)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError reports a response body or event payload that isn't valid JSON
+// for the expected type.
+type DecodeError struct {
+	What string
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("unable to decode %s: %s", e.What, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// UnsupportedEventError reports an event whose payload can't be rendered,
+// such as an unknown action.
+type UnsupportedEventError struct {
+	Type   string
+	Reason string
+}
+
+func (e *UnsupportedEventError) Error() string {
+	if e.Reason == "" {
+		return "unable to parse"
+	}
+	return "unable to parse, " + e.Reason
+}
+
+// PartialError reports a run that failed after some events were printed.
+type PartialError struct {
+	Printed int
+	Err     error
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%s (after printing %d events)", e.Err, e.Printed)
+}
+
+func (e *PartialError) Unwrap() error {
+	return e.Err
+}
+
+// exitCode maps err to the exit code documented in the README.
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	var usageErr *UsageError
+	if errors.As(err, &usageErr) {
+		return exitUsage
+	}
+
+	var partialErr *PartialError
+	if errors.As(err, &partialErr) {
+		return exitPartial
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.Kind {
+		case statusNotFound:
+			return exitNotFound
+		case statusRateLimited, statusSecondaryRateLimited:
+			return exitRateLimited
+		}
+	}
+
+	return exitError
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCode(t *testing.T) {
+	t.Run("Successfully validates exit code for success", func(t *testing.T) {
+		require.Equal(t, exitOK, exitCode(nil))
+	})
+
+	t.Run("Successfully validates exit code for usage error", func(t *testing.T) {
+		require.Equal(t, exitUsage, exitCode(&UsageError{Message: "expected exactly one USER_NAME argument"}))
+	})
+
+	t.Run("Successfully validates exit code for not found", func(t *testing.T) {
+		require.Equal(t, exitNotFound, exitCode(fmt.Errorf("fetching events: %w", &StatusError{StatusCode: 404, Kind: statusNotFound})))
+	})
+
+	t.Run("Successfully validates exit code for rate limited", func(t *testing.T) {
+		require.Equal(t, exitRateLimited, exitCode(&StatusError{StatusCode: 429, Kind: statusRateLimited}))
+	})
+
+	t.Run("Successfully validates exit code for partial output", func(t *testing.T) {
+		require.Equal(t, exitPartial, exitCode(&PartialError{Printed: 30, Err: &StatusError{StatusCode: 503, Kind: statusServerError}}))
+	})
+
+	t.Run("Successfully validates exit code for network error", func(t *testing.T) {
+		require.Equal(t, exitError, exitCode(&NetworkError{URL: apiBaseURL, Err: errors.New("connection refused")}))
+	})
+}
+
+func TestParseEventErrors(t *testing.T) {
+	t.Run("Successfully validates decode error for malformed payload", func(t *testing.T) {
+		s, err := parseEvent("PushEvent", json.RawMessage(`{"size": "one"}`), "devUser/awesome-project")
+		var decodeErr *DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, "PushEvent payload", decodeErr.What)
+		require.Empty(t, s)
+	})
+
+	t.Run("Successfully validates unsupported event error", func(t *testing.T) {
+		s, err := parseEvent("ReleaseEvent", json.RawMessage(`{"action": "deleted"}`), "devUser/awesome-project")
+		var unsupportedErr *UnsupportedEventError
+		require.ErrorAs(t, err, &unsupportedErr)
+		require.Equal(t, "ReleaseEvent", unsupportedErr.Type)
+		require.Empty(t, s)
+	})
+}
+
+func TestRunUsage(t *testing.T) {
+	t.Run("Successfully validates error for missing username", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		err := run(nil, &stdout, &stderr)
+		require.EqualError(t, err, "expected exactly one USER_NAME argument")
+		require.Equal(t, exitUsage, exitCode(err))
+		require.Contains(t, stderr.String(), "usage: github-activity")
+	})
+
+	t.Run("Successfully validates error for unknown flag", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--unknown", "devUser"}, &stdout, &stderr)
+		require.Equal(t, exitUsage, exitCode(err))
+	})
+}
@@ -0,0 +1,671 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The --where expression language combines comparisons of event fields:
+//
+//	type == "PullRequestEvent" && action in ["opened", "closed"] && repo =~ "^acme/"
+//
+// Fields are dotted paths into the event, such as payload.pull_request.title.
+// Missing fields are null, and ordering comparisons with null are false so
+// an expression can mention fields of several event types.
+
+// ExprError is a syntax or evaluation error of an expression, Pos is the
+// byte offset of the offending token.
+type ExprError struct {
+	Expr string
+	Pos  int
+	Msg  string
+}
+
+// Error reports the column of the error and points at it under the
+// expression.
+func (e *ExprError) Error() string {
+	column := utf8.RuneCountInString(e.Expr[:min(e.Pos, len(e.Expr))])
+	return fmt.Sprintf("column %d: %s\n  %s\n  %s^", column+1, e.Msg, e.Expr, strings.Repeat(" ", column))
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOp
+	tokenPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return "string " + t.text
+	case tokenNumber:
+		return "number " + t.text
+	}
+	return strconv.Quote(t.text)
+}
+
+// exprOperators are the operators in the order they are lexed, longest
+// first.
+var exprOperators = []string{"==", "!=", "<=", ">=", "=~", "!~", "&&", "||", "<", ">", "!"}
+
+// lexExpr splits an expression into tokens.
+func lexExpr(src string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(src) {
+		c, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case unicode.IsSpace(c):
+			i += size
+		case c == '"':
+			end := i + 1
+			for end < len(src) && src[end] != '"' {
+				if src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(src) {
+				return nil, &ExprError{Expr: src, Pos: i, Msg: "unterminated string"}
+			}
+			tokens = append(tokens, token{kind: tokenString, text: src[i : end+1], pos: i})
+			i = end + 1
+		case c >= '0' && c <= '9' || c == '-' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9':
+			end := i + 1
+			for end < len(src) && (src[end] >= '0' && src[end] <= '9' || src[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[i:end], pos: i})
+			i = end
+		case c == '_' || unicode.IsLetter(c):
+			end := i + size
+			for end < len(src) {
+				r, n := utf8.DecodeRuneInString(src[end:])
+				if r != '_' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				end += n
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[i:end], pos: i})
+			i = end
+		case strings.ContainsRune("()[],", c):
+			tokens = append(tokens, token{kind: tokenPunct, text: string(c), pos: i})
+			i++
+		default:
+			op := ""
+			for _, candidate := range exprOperators {
+				if strings.HasPrefix(src[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, &ExprError{Expr: src, Pos: i, Msg: fmt.Sprintf("unexpected character %q", c)}
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(src)}), nil
+}
+
+// exprNode is a node of a parsed expression.
+type exprNode interface {
+	eval(env map[string]any) (any, error)
+}
+
+type literalNode struct {
+	value any
+}
+
+type fieldNode struct {
+	path []string
+}
+
+type listNode struct {
+	items []exprNode
+}
+
+type notNode struct {
+	pos     int
+	operand exprNode
+}
+
+type logicalNode struct {
+	op          string
+	pos         int
+	left, right exprNode
+}
+
+type compareNode struct {
+	op          string
+	pos         int
+	left, right exprNode
+}
+
+type matchNode struct {
+	negate bool
+	pos    int
+	left   exprNode
+	re     *regexp.Regexp
+}
+
+type inNode struct {
+	negate      bool
+	pos         int
+	left, right exprNode
+}
+
+// exprParser is a recursive descent parser of the grammar:
+//
+//	or      = and { "||" and }
+//	and     = unary { "&&" unary }
+//	unary   = "!" unary | compare
+//	compare = primary [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) primary
+//	                  | ( "=~" | "!~" ) string
+//	                  | [ "not" ] "in" primary ]
+//	primary = string | number | "true" | "false" | "null" | field
+//	        | "(" or ")" | "[" [ or { "," or } ] "]"
+type exprParser struct {
+	src    string
+	tokens []token
+	i      int
+}
+
+func (p *exprParser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *exprParser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokenEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *exprParser) errorf(pos int, format string, args ...any) error {
+	return &ExprError{Expr: p.src, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *exprParser) accept(kind tokenKind, text string) bool {
+	if t := p.peek(); t.kind == kind && t.text == text {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *exprParser) expect(kind tokenKind, text string) error {
+	if !p.accept(kind, text) {
+		t := p.peek()
+		return p.errorf(t.pos, "unexpected %s, expected %q", t, text)
+	}
+	return nil
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if !p.accept(tokenOp, "||") {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "||", pos: t.pos, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if !p.accept(tokenOp, "&&") {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{op: "&&", pos: t.pos, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	t := p.peek()
+	if p.accept(tokenOp, "!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{pos: t.pos, operand: operand}, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *exprParser) parseCompare() (exprNode, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	switch {
+	case t.kind == tokenOp && (t.text == "=~" || t.text == "!~"):
+		p.next()
+		pattern := p.next()
+		if pattern.kind != tokenString {
+			return nil, p.errorf(pattern.pos, "unexpected %s, expected a regular expression string", pattern)
+		}
+		s, err := strconv.Unquote(pattern.text)
+		if err != nil {
+			return nil, p.errorf(pattern.pos, "invalid string %s", pattern.text)
+		}
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return nil, p.errorf(pattern.pos, "invalid regular expression: %s", err)
+		}
+		return &matchNode{negate: t.text == "!~", pos: t.pos, left: left, re: re}, nil
+	case t.kind == tokenOp && t.text != "&&" && t.text != "||" && t.text != "!":
+		p.next()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return &compareNode{op: t.text, pos: t.pos, left: left, right: right}, nil
+	case t.kind == tokenIdent && (t.text == "in" || t.text == "not"):
+		p.next()
+		negate := t.text == "not"
+		if negate {
+			if err := p.expect(tokenIdent, "in"); err != nil {
+				return nil, err
+			}
+		}
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return &inNode{negate: negate, pos: t.pos, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		s, err := strconv.Unquote(t.text)
+		if err != nil {
+			return nil, p.errorf(t.pos, "invalid string %s", t.text)
+		}
+		return &literalNode{value: s}, nil
+	case tokenNumber:
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, p.errorf(t.pos, "invalid number %s", t.text)
+		}
+		return &literalNode{value: n}, nil
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		case "in", "not":
+			return nil, p.errorf(t.pos, "unexpected %s, expected a value", t)
+		}
+		path := strings.Split(t.text, ".")
+		for _, name := range path {
+			if name == "" {
+				return nil, p.errorf(t.pos, "invalid field %s", t.text)
+			}
+		}
+		return &fieldNode{path: path}, nil
+	case tokenPunct:
+		switch t.text {
+		case "(":
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return node, p.expect(tokenPunct, ")")
+		case "[":
+			list := &listNode{}
+			if p.accept(tokenPunct, "]") {
+				return list, nil
+			}
+			for {
+				item, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				list.items = append(list.items, item)
+				if p.accept(tokenPunct, "]") {
+					return list, nil
+				}
+				if err := p.expect(tokenPunct, ","); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return nil, p.errorf(t.pos, "unexpected %s, expected a value", t)
+}
+
+// whereExpr is a compiled --where expression.
+type whereExpr struct {
+	src  string
+	root exprNode
+}
+
+// compileWhere parses an expression.
+func compileWhere(src string) (*whereExpr, error) {
+	tokens, err := lexExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{src: src, tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorf(t.pos, "unexpected %s, expected end of expression", t)
+	}
+	return &whereExpr{src: src, root: root}, nil
+}
+
+// eventEnv returns the fields of event addressable by expressions.
+func eventEnv(event RawEvent) map[string]any {
+	var payload any
+	if json.Unmarshal(event.Payload, &payload) != nil {
+		payload = nil
+	}
+	env := map[string]any{
+		"id":         event.ID,
+		"type":       event.Type,
+		"action":     nil,
+		"repo":       event.Repo.Name,
+		"actor":      event.Actor.Login,
+		"created_at": nil,
+		"payload":    payload,
+	}
+	if action := eventAction(event.Payload); action != "" {
+		env["action"] = action
+	}
+	if !event.CreatedAt.IsZero() {
+		env["created_at"] = event.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	return env
+}
+
+// eval evaluates the expression against env, which must give a boolean or
+// null, counted as false.
+func (w *whereExpr) eval(env map[string]any) (bool, error) {
+	v, err := w.root.eval(env)
+	if err == nil {
+		var b bool
+		if b, err = truthy(v, 0); err == nil {
+			return b, nil
+		}
+	}
+
+	var exprErr *ExprError
+	if errors.As(err, &exprErr) {
+		exprErr.Expr = w.src
+	}
+	return false, err
+}
+
+// match reports whether event satisfies the expression.
+func (w *whereExpr) match(event RawEvent) (bool, error) {
+	return w.eval(eventEnv(event))
+}
+
+// truthy converts a value used as a condition, the error lacks the source of
+// the expression, which whereExpr.eval fills in.
+func truthy(v any, pos int) (bool, error) {
+	switch v := v.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	}
+	return false, &ExprError{Pos: pos, Msg: fmt.Sprintf("expected a boolean, got %s", describeValue(v))}
+}
+
+func describeValue(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64, json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "list"
+	}
+	return "object"
+}
+
+func (n *literalNode) eval(map[string]any) (any, error) {
+	return n.value, nil
+}
+
+func (n *fieldNode) eval(env map[string]any) (any, error) {
+	var v any = env
+	for _, name := range n.path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, nil
+		}
+		v = m[name]
+	}
+	return v, nil
+}
+
+func (n *listNode) eval(env map[string]any) (any, error) {
+	items := make([]any, len(n.items))
+	for i, item := range n.items {
+		v, err := item.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = v
+	}
+	return items, nil
+}
+
+func (n *notNode) eval(env map[string]any) (any, error) {
+	v, err := n.operand.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	b, err := truthy(v, n.pos)
+	return !b, err
+}
+
+func (n *logicalNode) eval(env map[string]any) (any, error) {
+	v, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	left, err := truthy(v, n.pos)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "&&" && !left || n.op == "||" && left {
+		return left, nil
+	}
+
+	if v, err = n.right.eval(env); err != nil {
+		return nil, err
+	}
+	return truthy(v, n.pos)
+}
+
+func (n *compareNode) eval(env map[string]any) (any, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return valuesEqual(left, right), nil
+	case "!=":
+		return !valuesEqual(left, right), nil
+	}
+
+	if left == nil || right == nil {
+		return false, nil
+	}
+	var cmp int
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return nil, n.mismatch(left, right)
+		}
+		cmp = compareOrdered(l, r)
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return nil, n.mismatch(left, right)
+		}
+		cmp = strings.Compare(l, r)
+	default:
+		return nil, n.mismatch(left, right)
+	}
+
+	switch n.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	}
+	return cmp >= 0, nil
+}
+
+func (n *compareNode) mismatch(left, right any) error {
+	return &ExprError{Pos: n.pos, Msg: fmt.Sprintf("can't compare %s and %s with %s", describeValue(left), describeValue(right), n.op)}
+}
+
+func compareOrdered(l, r float64) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	}
+	return 0
+}
+
+// valuesEqual compares values of the same JSON type, values of different
+// types are never equal.
+func valuesEqual(left, right any) bool {
+	switch l := left.(type) {
+	case nil:
+		return right == nil
+	case bool, float64, string:
+		return left == right
+	case []any:
+		r, ok := right.([]any)
+		if !ok || len(l) != len(r) {
+			return false
+		}
+		for i := range l {
+			if !valuesEqual(l[i], r[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (n *matchNode) eval(env map[string]any) (any, error) {
+	v, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case nil:
+		return false, nil
+	case string:
+		return n.re.MatchString(v) != n.negate, nil
+	}
+	return nil, &ExprError{Pos: n.pos, Msg: fmt.Sprintf("can't match %s against a regular expression", describeValue(v))}
+}
+
+func (n *inNode) eval(env map[string]any) (any, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r := right.(type) {
+	case nil:
+		return n.negate, nil
+	case []any:
+		for _, item := range r {
+			if valuesEqual(left, item) {
+				return !n.negate, nil
+			}
+		}
+		return n.negate, nil
+	case string:
+		// Null and empty strings are in no string, rather than in all of
+		// them, so fields missing from an event never match.
+		if left == nil {
+			return n.negate, nil
+		}
+		if l, ok := left.(string); ok {
+			return (l != "" && strings.Contains(r, l)) != n.negate, nil
+		}
+	}
+	return nil, &ExprError{Pos: n.pos, Msg: fmt.Sprintf("can't look for %s in %s", describeValue(left), describeValue(right))}
+}
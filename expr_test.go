@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWhereExpr(t *testing.T) {
+	push := decodeRawEvent(t, pushEventJSON)
+	pr := decodeRawEvent(t, pullRequestEventJSON)
+
+	match := func(t *testing.T, src string, event RawEvent) bool {
+		t.Helper()
+		w, err := compileWhere(src)
+		require.Nil(t, err)
+		ok, err := w.match(event)
+		require.Nil(t, err)
+		return ok
+	}
+
+	t.Run("Successfully validates comparisons of event fields", func(t *testing.T) {
+		src := `type == "PullRequestEvent" && action in ["opened", "closed"] && repo =~ "^devUser/"`
+		require.True(t, match(t, src, pr))
+		require.False(t, match(t, src, push))
+	})
+
+	t.Run("Successfully validates payload fields", func(t *testing.T) {
+		require.True(t, match(t, `payload.pull_request.title =~ "(?i)feature"`, pr))
+		require.True(t, match(t, `payload.size >= 2 && payload.size < 3`, push))
+		require.True(t, match(t, `created_at > "2024-11-28T14:30:00Z"`, pr))
+	})
+
+	t.Run("Successfully validates missing fields are null", func(t *testing.T) {
+		require.False(t, match(t, `payload.size > 1`, pr))
+		require.True(t, match(t, `payload.pull_request.merged == null`, pr))
+		require.True(t, match(t, `!(payload.size > 1) || type == "PushEvent"`, pr))
+	})
+
+	t.Run("Successfully validates not in and substrings", func(t *testing.T) {
+		require.True(t, match(t, `actor not in ["bot", "dependabot"]`, push))
+		require.True(t, match(t, `"awesome" in repo`, push))
+		require.True(t, match(t, `repo !~ "^acme/"`, push))
+	})
+
+	t.Run("Successfully validates missing action is null and in no string", func(t *testing.T) {
+		require.True(t, match(t, `action == null`, push))
+		require.False(t, match(t, `action in "opened"`, push))
+		require.True(t, match(t, `action not in "opened"`, push))
+		require.True(t, match(t, `action in "opened"`, pr))
+		require.False(t, match(t, `"" in repo`, push))
+	})
+
+	t.Run("Successfully validates non-ASCII identifiers", func(t *testing.T) {
+		event := decodeRawEvent(t, `{"type": "PushEvent", "repo": {"name": "devUser/awesome-project"}, "payload": {"tïtle": "x"}}`)
+		require.True(t, match(t, `payload.tïtle == "x"`, event))
+
+		_, err := compileWhere(`payload.tïtle == "x" § 1`)
+		require.EqualError(t, err, "column 22: unexpected character '§'\n"+
+			"  payload.tïtle == \"x\" § 1\n"+
+			"                       ^")
+	})
+
+	t.Run("Successfully validates syntax error positions", func(t *testing.T) {
+		_, err := compileWhere(`type == "PushEvent" && (action == "opened"`)
+		require.EqualError(t, err, "column 43: unexpected end of expression, expected \")\"\n"+
+			"  type == \"PushEvent\" && (action == \"opened\"\n"+
+			"                                            ^")
+
+		_, err = compileWhere(`repo =~ "(" `)
+		var exprErr *ExprError
+		require.ErrorAs(t, err, &exprErr)
+		require.Equal(t, 8, exprErr.Pos)
+
+		_, err = compileWhere(`type == "PushEvent" extra`)
+		require.ErrorAs(t, err, &exprErr)
+		require.Equal(t, 20, exprErr.Pos)
+	})
+
+	t.Run("Successfully validates evaluation error positions", func(t *testing.T) {
+		w, err := compileWhere(`payload.size > "two"`)
+		require.Nil(t, err)
+		_, err = w.match(push)
+		var exprErr *ExprError
+		require.ErrorAs(t, err, &exprErr)
+		require.Equal(t, 13, exprErr.Pos)
+		require.Equal(t, "can't compare number and string with >", exprErr.Msg)
+		require.Equal(t, `payload.size > "two"`, exprErr.Expr)
+	})
+}
+
+func TestRunWhere(t *testing.T) {
+	t.Run("Successfully validates --where", func(t *testing.T) {
+		setupRun(t, newTestServer(t, []string{"[" + pushEventJSON + "," + pullRequestEventJSON + "]"}))
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--time", "iso", "--tz", "UTC", "--where", `payload.number == 42`, "devUser"}, &stdout, &stderr)
+		require.Nil(t, err)
+		require.Equal(t, "2024-11-28T15:00:00Z  Pull request 42. Add feature X for devUser/awesome-project is opened at https://api.github.com/repos/devUser/awesome-project/pulls/42\n", stdout.String())
+	})
+
+	t.Run("Successfully validates error for invalid --where", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--where", `type = "PushEvent"`, "devUser"}, &stdout, &stderr)
+		require.ErrorContains(t, err, "invalid --where expression at column 6: unexpected character '='")
+		require.Equal(t, exitUsage, exitCode(err))
+	})
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// eventFilter selects the events to print. Empty fields match every event.
+type eventFilter struct {
+	Types   []string
+	Actions []string
+	// Repos and ExcludeRepos are path.Match globs such as org/*, matched
+	// case-insensitively.
+	Repos        []string
+	ExcludeRepos []string
+	// Since is inclusive, Until is exclusive.
+	Since time.Time
+	Until time.Time
+}
+
+// splitList splits a comma separated flag value, ignoring empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseRepoGlobs splits and validates the globs of a --repo or
+// --exclude-repo flag.
+func parseRepoGlobs(flagName, s string) ([]string, error) {
+	globs := splitList(strings.ToLower(s))
+	for _, glob := range globs {
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, &UsageError{Message: fmt.Sprintf("invalid %s pattern %q", flagName, glob)}
+		}
+	}
+	return globs, nil
+}
+
+var relativeTimeBound = regexp.MustCompile(`^(\d+)([mhdw])$`)
+
+var timeBoundUnits = map[string]time.Duration{
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// parseTimeBound parses the value of --since or --until: a duration before
+// now such as 30m, 12h, 7d or 2w, a date such as 2026-10-01 in location, or
+// an RFC 3339 time. A date used as an end bound includes the whole day.
+func parseTimeBound(flagName, s string, now time.Time, location *time.Location, end bool) (time.Time, error) {
+	if m := relativeTimeBound.FindStringSubmatch(s); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err == nil {
+			return now.Add(-time.Duration(n) * timeBoundUnits[m[2]]), nil
+		}
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, s, location); err == nil {
+		if end {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, &UsageError{Message: fmt.Sprintf("invalid %s time %q, expected a duration such as 7d, a date such as 2026-10-01 or an RFC 3339 time", flagName, s)}
+}
+
+func containsString(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesGlob(globs []string, s string) bool {
+	s = strings.ToLower(s)
+	for _, glob := range globs {
+		if ok, _ := path.Match(glob, s); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether event passes the filter.
+func (f *eventFilter) match(event RawEvent) bool {
+	if len(f.Types) > 0 && !containsString(f.Types, event.Type) {
+		return false
+	}
+	if len(f.Actions) > 0 && !containsString(f.Actions, eventAction(event.Payload)) {
+		return false
+	}
+	if len(f.Repos) > 0 && !matchesGlob(f.Repos, event.Repo.Name) {
+		return false
+	}
+	if matchesGlob(f.ExcludeRepos, event.Repo.Name) {
+		return false
+	}
+	if !f.Since.IsZero() && event.CreatedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !event.CreatedAt.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// exhausted reports whether event and, the API returning the newest events
+// first, every event after it are older than the window.
+func (f *eventFilter) exhausted(event RawEvent) bool {
+	return !f.Since.IsZero() && !event.CreatedAt.IsZero() && event.CreatedAt.Before(f.Since)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeBound(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Successfully validates durations ago", func(t *testing.T) {
+		since, err := parseTimeBound("--since", "7d", now, time.UTC, false)
+		require.Nil(t, err)
+		require.Equal(t, time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC), since)
+
+		since, err = parseTimeBound("--since", "90m", now, time.UTC, false)
+		require.Nil(t, err)
+		require.Equal(t, time.Date(2026, 10, 17, 10, 30, 0, 0, time.UTC), since)
+	})
+
+	t.Run("Successfully validates dates", func(t *testing.T) {
+		since, err := parseTimeBound("--since", "2026-10-01", now, time.UTC, false)
+		require.Nil(t, err)
+		require.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), since)
+
+		until, err := parseTimeBound("--until", "2026-10-01", now, time.UTC, true)
+		require.Nil(t, err)
+		require.Equal(t, time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC), until)
+	})
+
+	t.Run("Successfully validates RFC 3339 time", func(t *testing.T) {
+		until, err := parseTimeBound("--until", "2026-10-01T08:00:00+02:00", now, time.UTC, true)
+		require.Nil(t, err)
+		require.True(t, until.Equal(time.Date(2026, 10, 1, 6, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("Successfully validates error for invalid time", func(t *testing.T) {
+		_, err := parseTimeBound("--since", "last week", now, time.UTC, false)
+		require.ErrorContains(t, err, `invalid --since time "last week"`)
+		require.Equal(t, exitUsage, exitCode(err))
+	})
+}
+
+func TestEventFilter(t *testing.T) {
+	push := decodeRawEvent(t, pushEventJSON)
+	pr := decodeRawEvent(t, pullRequestEventJSON)
+
+	t.Run("Successfully validates type and action", func(t *testing.T) {
+		f := eventFilter{Types: []string{"PullRequestEvent"}}
+		require.False(t, f.match(push))
+		require.True(t, f.match(pr))
+
+		f = eventFilter{Actions: []string{"closed"}}
+		require.False(t, f.match(pr))
+	})
+
+	t.Run("Successfully validates repository globs", func(t *testing.T) {
+		globs, err := parseRepoGlobs("--repo", "DevUser/*")
+		require.Nil(t, err)
+		f := eventFilter{Repos: globs}
+		require.True(t, f.match(push))
+
+		f = eventFilter{ExcludeRepos: []string{"devuser/awesome-*"}}
+		require.False(t, f.match(push))
+	})
+
+	t.Run("Successfully validates error for invalid glob", func(t *testing.T) {
+		_, err := parseRepoGlobs("--exclude-repo", "org/[")
+		require.EqualError(t, err, `invalid --exclude-repo pattern "org/["`)
+	})
+
+	t.Run("Successfully validates time window", func(t *testing.T) {
+		f := eventFilter{
+			Since: time.Date(2024, 11, 28, 14, 30, 0, 0, time.UTC),
+			Until: time.Date(2024, 11, 28, 15, 0, 0, 0, time.UTC),
+		}
+		require.False(t, f.match(push))
+		require.True(t, f.exhausted(push))
+		require.False(t, f.match(pr))
+		require.False(t, f.exhausted(pr))
+	})
+}
+
+func TestRunFilter(t *testing.T) {
+	pages := []string{
+		`[{"type": "PushEvent", "repo": {"name": "org/one"}, "payload": {"size": 1}, "created_at": "2026-10-16T12:00:00Z"},
+		  {"type": "WatchEvent", "repo": {"name": "org/two"}, "payload": {"action": "started"}, "created_at": "2026-10-15T12:00:00Z"},
+		  {"type": "PushEvent", "repo": {"name": "other/three"}, "payload": {"size": 3}, "created_at": "2026-10-14T12:00:00Z"}]`,
+		`[{"type": "PushEvent", "repo": {"name": "org/four"}, "payload": {"size": 4}, "created_at": "2026-09-01T12:00:00Z"}]`,
+		`[{"type": "PushEvent", "repo": {"name": "org/five"}, "payload": {"size": 5}, "created_at": "2026-08-01T12:00:00Z"}]`,
+	}
+
+	t.Run("Successfully validates type and repository filters", func(t *testing.T) {
+		setupRun(t, newTestServer(t, pages))
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--time", "iso", "--tz", "UTC", "--type", "PushEvent", "--repo", "org/*", "--exclude-repo", "org/five", "devUser"}, &stdout, &stderr)
+		require.Nil(t, err)
+		require.Equal(t, "2026-10-16T12:00:00Z  Pushed 1 commit to org/one\n"+
+			"2026-09-01T12:00:00Z  Pushed 4 commits to org/four\n", stdout.String())
+	})
+
+	t.Run("Successfully validates --since stops pagination", func(t *testing.T) {
+		var requested []string
+		srv := newTestServer(t, pages)
+		next := srv.Config.Handler
+		srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested = append(requested, r.URL.Query().Get("page"))
+			next.ServeHTTP(w, r)
+		})
+		setupRun(t, srv)
+
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--time", "iso", "--tz", "UTC", "--type", "PushEvent", "--since", "2026-09-15", "--until", "2026-10-15", "devUser"}, &stdout, &stderr)
+		require.Nil(t, err)
+		require.Equal(t, "2026-10-14T12:00:00Z  Pushed 3 commits to other/three\n", stdout.String())
+		require.Equal(t, []string{"", "2"}, requested)
+	})
+
+	t.Run("Successfully validates --limit counts matching events", func(t *testing.T) {
+		setupRun(t, newTestServer(t, pages))
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--time", "iso", "--tz", "UTC", "--repo", "org/*", "--type", "PushEvent", "--limit", "2", "devUser"}, &stdout, &stderr)
+		require.Nil(t, err)
+		require.Equal(t, 2, strings.Count(stdout.String(), "\n"))
+		require.Contains(t, stdout.String(), "org/four")
+	})
+}
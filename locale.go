@@ -0,0 +1,301 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// locale holds the translations and formatting rules of a language.
+type locale struct {
+	Tag string
+	// Plural returns the CLDR plural category of n: zero, one, two, few,
+	// many or other.
+	Plural func(n int) string
+	// Messages translates defaultMessages, missing keys fall back to English.
+	Messages map[string]string
+	// JustNow, Future and Ago render relative times, Ago holds the plural
+	// forms of every unit.
+	JustNow string
+	Future  string
+	Ago     map[string]map[string]string
+	// Today and Yesterday head the events of the last two days in terminals.
+	Today     string
+	Yesterday string
+	Weekdays  [7]string
+	Months    [12]string
+}
+
+var englishLocale = &locale{
+	Tag: "en",
+	Plural: func(n int) string {
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	},
+	Messages: defaultMessages,
+	JustNow:  "just now",
+	Future:   "in the future",
+	Ago: map[string]map[string]string{
+		"minute": {"one": "%d minute ago", "other": "%d minutes ago"},
+		"hour":   {"one": "%d hour ago", "other": "%d hours ago"},
+		"day":    {"one": "%d day ago", "other": "%d days ago"},
+		"month":  {"one": "%d month ago", "other": "%d months ago"},
+		"year":   {"one": "%d year ago", "other": "%d years ago"},
+	},
+	Today:     "Today",
+	Yesterday: "Yesterday",
+	Weekdays:  [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	Months:    [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+}
+
+var frenchLocale = &locale{
+	Tag: "fr",
+	Plural: func(n int) string {
+		switch {
+		case n == 0 || n == 1:
+			return "one"
+		case n%1000000 == 0:
+			return "many"
+		}
+		return "other"
+	},
+	Messages: frenchMessages,
+	JustNow:  "à l'instant",
+	Future:   "dans le futur",
+	Ago: map[string]map[string]string{
+		"minute": {"one": "il y a %d minute", "other": "il y a %d minutes"},
+		"hour":   {"one": "il y a %d heure", "other": "il y a %d heures"},
+		"day":    {"one": "il y a %d jour", "other": "il y a %d jours"},
+		"month":  {"one": "il y a %d mois", "other": "il y a %d mois"},
+		"year":   {"one": "il y a %d an", "other": "il y a %d ans"},
+	},
+	Today:     "Aujourd'hui",
+	Yesterday: "Hier",
+	Weekdays:  [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+	Months:    [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+}
+
+var frenchMessages = map[string]string{
+	"CreateEvent.repository": "Nouveau dépôt {{.Repo}} créé{{with .MasterBranch}} avec la branche par défaut {{.}}{{end}}{{with .Description}} : {{.}}{{end}}",
+	"CreateEvent.branch":     "{{with .Ref}}Branche {{.}}{{else}}Une branche{{end}} créée dans {{.Repo}}",
+	"CreateEvent.tag":        "{{with .Ref}}Tag {{.}}{{else}}Un tag{{end}} créé dans {{.Repo}}",
+
+	"DeleteEvent.branch": "{{with .Ref}}Branche {{.}}{{else}}Une branche{{end}} supprimée dans {{.Repo}}",
+	"DeleteEvent.tag":    "{{with .Ref}}Tag {{.}}{{else}}Un tag{{end}} supprimé dans {{.Repo}}",
+
+	"IssuesEvent.opened":     "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} est ouvert",
+	"IssuesEvent.edited":     "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} est modifié",
+	"IssuesEvent.closed":     "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} est fermé",
+	"IssuesEvent.reopened":   "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} est rouvert",
+	"IssuesEvent.assigned":   "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} est assigné à {{.Assignee.Login}}",
+	"IssuesEvent.unassigned": "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} n'est plus assigné à {{.Assignee.Login}}",
+	"IssuesEvent.labeled":    "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} a reçu le label {{.Label.Name}}",
+	"IssuesEvent.unlabeled":  "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} a perdu le label {{.Label.Name}}",
+
+	"IssuesEvent.deleted":      "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} est supprimé",
+	"IssuesEvent.transferred":  "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} est transféré vers {{.Changes.NewRepository.FullName}}{{with .Changes.NewIssue.Url}} sur {{.}}{{end}}",
+	"IssuesEvent.pinned":       "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} est épinglé",
+	"IssuesEvent.unpinned":     "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} n'est plus épinglé",
+	"IssuesEvent.locked":       "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} est verrouillé{{with .Issue.ActiveLockReason}} pour le motif {{.}}{{end}}",
+	"IssuesEvent.unlocked":     "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} est déverrouillé",
+	"IssuesEvent.milestoned":   "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} est ajouté au jalon {{.Milestone.Title}}",
+	"IssuesEvent.demilestoned": "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} est retiré du jalon {{.Milestone.Title}}",
+	"IssuesEvent.typed":        "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} est de type {{.IssueType.Name}}",
+	"IssuesEvent.untyped":      "Ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} n'est plus de type {{.IssueType.Name}}",
+
+	"PullRequestEvent.opened":                 "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} est ouverte{{if .PullRequest.Draft}} en brouillon{{end}}{{with .PullRequest.Head.Ref}} depuis {{.}}{{end}}{{with .PullRequest.Base.Ref}} vers {{.}}{{end}} sur {{.PullRequest.Url}}",
+	"PullRequestEvent.closed":                 "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} est {{if .IsMerged}}fusionnée{{with .PullRequest.Base.Ref}} dans {{.}}{{end}}{{else}}fermée sans fusion{{end}} sur {{.PullRequest.Url}}",
+	"PullRequestEvent.reopened":               "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} est rouverte sur {{.PullRequest.Url}}",
+	"PullRequestEvent.edited":                 "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} est modifiée sur {{.PullRequest.Url}}",
+	"PullRequestEvent.assigned":               "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} est assignée à {{.Assignee.Login}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.unassigned":             "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} n'est plus assignée à {{.Assignee.Login}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.labeled":                "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} a reçu le label {{.Label.Name}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.unlabeled":              "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} a perdu le label {{.Label.Name}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.review_requested":       "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} attend la relecture de {{or .RequestedReviewer.Login .RequestedTeam.Name}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.review_request_removed": "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} n'attend plus la relecture de {{or .RequestedReviewer.Login .RequestedTeam.Name}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.ready_for_review":       "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} est prête pour la relecture sur {{.PullRequest.Url}}",
+	"PullRequestEvent.converted_to_draft":     "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} est repassée en brouillon sur {{.PullRequest.Url}}",
+	"PullRequestEvent.synchronize":            "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} est synchronisée, {{.PullRequest.Url}}",
+	"PullRequestEvent.locked":                 "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} est verrouillée sur {{.PullRequest.Url}}",
+	"PullRequestEvent.unlocked":               "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} est déverrouillée sur {{.PullRequest.Url}}",
+	"PullRequestEvent.milestoned":             "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} est ajoutée au jalon {{.PullRequest.Milestone.Title}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.demilestoned":           "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} est retirée du jalon {{.PullRequest.Milestone.Title}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.auto_merge_enabled":     "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} a la fusion automatique activée sur {{.PullRequest.Url}}",
+	"PullRequestEvent.auto_merge_disabled":    "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} a la fusion automatique désactivée{{with .Reason}} ({{.}}){{end}} sur {{.PullRequest.Url}}",
+	"PullRequestEvent.enqueued":               "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} est ajoutée à la file de fusion sur {{.PullRequest.Url}}",
+	"PullRequestEvent.dequeued":               "Pull request {{.Number}}. {{.PullRequest.Title}} pour {{.Repo}} est retirée de la file de fusion{{with .Reason}} ({{.}}){{end}} sur {{.PullRequest.Url}}",
+
+	"PushEvent":         `{{with .Size}}{{.}} {{plural . "one:commit poussé" "other:commits poussés"}}{{else}}Push{{end}}{{if .Existing}} ({{.DistinctSize}} {{plural .DistinctSize "one:nouveau" "other:nouveaux"}}){{end}} vers {{with .Tag}}le tag {{.}} dans {{else}}{{with .Branch}}{{.}} dans {{end}}{{end}}{{.Repo}}`,
+	"PushEvent.created": `{{with .Tag}}Nouveau tag {{.}} poussé{{else}}Nouvelle branche {{.Branch}} poussée{{end}} vers {{.Repo}}`,
+	"PushEvent.deleted": `{{with .Tag}}Tag {{.}} supprimé{{else}}Branche {{.Branch}} supprimée{{end}} dans {{.Repo}} par un push`,
+	"PushEvent.forced":  `Push forcé sur {{with .Tag}}le tag {{.}} dans {{else}}{{with .Branch}}{{.}} dans {{end}}{{end}}{{.Repo}} de {{shortSHA .Before}} à {{shortSHA .Head}}`,
+
+	"ReleaseEvent.published":   "{{.Release.Name}} publiée sur {{.Release.Url}}",
+	"ReleaseEvent.prereleased": "{{.Release.Name}} pré-publiée sur {{.Release.Url}}",
+	"ReleaseEvent.created":     "{{.Release.Name}} créée sur {{.Release.Url}}",
+
+	"ForkEvent":          "Fork de {{.Repo}} vers {{.Forkee.FullName}} sur {{.Forkee.Url}}",
+	"WatchEvent.started": "Étoile ajoutée à {{.Repo}}",
+	"PublicEvent":        "{{.Repo}} rendu public",
+
+	"IssueCommentEvent.created": `Commentaire sur le ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} : « {{excerpt .Comment.Body}} » sur {{.Comment.Url}}`,
+	"IssueCommentEvent.edited":  `Commentaire modifié sur le ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} : « {{excerpt .Comment.Body}} » sur {{.Comment.Url}}`,
+	"IssueCommentEvent.deleted": `Commentaire supprimé sur le ticket {{.Issue.Number}}. {{.Issue.Title}} pour {{.Repo}} : « {{excerpt .Comment.Body}} »`,
+
+	"CommitCommentEvent.created": `Commentaire sur le commit {{shortSHA .Comment.CommitID}} pour {{.Repo}} : « {{excerpt .Comment.Body}} » sur {{.Comment.Url}}`,
+	"CommitCommentEvent.edited":  `Commentaire modifié sur le commit {{shortSHA .Comment.CommitID}} pour {{.Repo}} : « {{excerpt .Comment.Body}} » sur {{.Comment.Url}}`,
+	"CommitCommentEvent.deleted": `Commentaire supprimé sur le commit {{shortSHA .Comment.CommitID}} pour {{.Repo}} : « {{excerpt .Comment.Body}} »`,
+
+	"GollumEvent.created": "Page de wiki {{.Title}} créée pour {{.Repo}} sur {{.Url}}",
+	"GollumEvent.edited":  "Page de wiki {{.Title}} modifiée pour {{.Repo}} sur {{.Url}}",
+
+	"MemberEvent.added":   "{{.Member.Login}} ajouté comme collaborateur de {{.Repo}}",
+	"MemberEvent.removed": "{{.Member.Login}} retiré des collaborateurs de {{.Repo}}",
+	"MemberEvent.edited":  "Permission de {{.Member.Login}} sur {{.Repo}} modifiée{{with .Changes.Permission}}{{if .To}} de {{or .From \"aucune\"}} à {{.To}}{{end}}{{end}}",
+
+	"PullRequestReviewEvent.approved":          "Pull request {{.PullRequest.Number}}. {{.PullRequest.Title}} pour {{.Repo}} approuvée sur {{.Review.Url}}",
+	"PullRequestReviewEvent.changes_requested": "Modifications demandées sur la pull request {{.PullRequest.Number}}. {{.PullRequest.Title}} pour {{.Repo}} sur {{.Review.Url}}",
+	"PullRequestReviewEvent.commented":         "Pull request {{.PullRequest.Number}}. {{.PullRequest.Title}} pour {{.Repo}} relue avec des commentaires sur {{.Review.Url}}",
+	"PullRequestReviewEvent.dismissed":         "Relecture de la pull request {{.PullRequest.Number}}. {{.PullRequest.Title}} pour {{.Repo}} rejetée sur {{.Review.Url}}",
+
+	"PullRequestReviewCommentEvent.created": `Commentaire sur la pull request {{.PullRequest.Number}}. {{.PullRequest.Title}} pour {{.Repo}} : « {{excerpt .Comment.Body}} » sur {{.Comment.Url}}`,
+	"PullRequestReviewCommentEvent.edited":  `Commentaire modifié sur la pull request {{.PullRequest.Number}}. {{.PullRequest.Title}} pour {{.Repo}} : « {{excerpt .Comment.Body}} » sur {{.Comment.Url}}`,
+	"PullRequestReviewCommentEvent.deleted": `Commentaire supprimé sur la pull request {{.PullRequest.Number}}. {{.PullRequest.Title}} pour {{.Repo}} : « {{excerpt .Comment.Body}} »`,
+}
+
+// locales are the supported languages, keyed by language code.
+var locales = map[string]*locale{
+	englishLocale.Tag: englishLocale,
+	frenchLocale.Tag:  frenchLocale,
+}
+
+// localeLanguage returns the language code of a POSIX locale name such as
+// fr_FR.UTF-8.
+func localeLanguage(name string) string {
+	name, _, _ = strings.Cut(name, ".")
+	name, _, _ = strings.Cut(name, "@")
+	name, _, _ = strings.Cut(name, "_")
+	name, _, _ = strings.Cut(name, "-")
+	return strings.ToLower(name)
+}
+
+// resolveLocale returns the locale named by the --locale flag, or else by
+// the LC_ALL, LC_MESSAGES and LANG environment variables. Unsupported
+// environment locales fall back to English, an unsupported flag value is a
+// usage error.
+func resolveLocale(flagLocale string, getenv func(string) string) (*locale, error) {
+	if flagLocale != "" {
+		if loc, ok := locales[localeLanguage(flagLocale)]; ok {
+			return loc, nil
+		}
+		tags := make([]string, 0, len(locales))
+		for tag := range locales {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+		return nil, &UsageError{Message: fmt.Sprintf("unsupported locale %q, expected one of %s", flagLocale, strings.Join(tags, ", "))}
+	}
+
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := getenv(name); value != "" {
+			if loc, ok := locales[localeLanguage(value)]; ok {
+				return loc, nil
+			}
+			return englishLocale, nil
+		}
+	}
+	return englishLocale, nil
+}
+
+// pluralForm picks the form matching the plural category of n. Forms are
+// "category:text" pairs, such as "one:commit" "other:commits", or a
+// singular and a plural form. The other form is used when the category has
+// no form of its own.
+func (loc *locale) pluralForm(n int, forms ...string) string {
+	byCategory := make(map[string]string)
+	for i, form := range forms {
+		category, text, ok := strings.Cut(form, ":")
+		if !ok || !isPluralCategory(category) {
+			category, text = "other", form
+			if i == 0 && len(forms) == 2 {
+				category = "one"
+			}
+		}
+		byCategory[category] = text
+	}
+
+	if text, ok := byCategory[loc.Plural(n)]; ok {
+		return text
+	}
+	return byCategory["other"]
+}
+
+func isPluralCategory(s string) bool {
+	switch s {
+	case "zero", "one", "two", "few", "many", "other":
+		return true
+	}
+	return false
+}
+
+// relativeTime describes t relative to now, such as "3 hours ago".
+func (loc *locale) relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	if d < 0 {
+		return loc.Future
+	}
+
+	var n int
+	var unit string
+	switch {
+	case d < time.Minute:
+		return loc.JustNow
+	case d < time.Hour:
+		n, unit = int(d/time.Minute), "minute"
+	case d < 24*time.Hour:
+		n, unit = int(d/time.Hour), "hour"
+	case d < 30*24*time.Hour:
+		n, unit = int(d/(24*time.Hour)), "day"
+	case d < 365*24*time.Hour:
+		n, unit = int(d/(30*24*time.Hour)), "month"
+	default:
+		n, unit = int(d/(365*24*time.Hour)), "year"
+	}
+
+	forms := loc.Ago[unit]
+	format, ok := forms[loc.Plural(n)]
+	if !ok {
+		format = forms["other"]
+	}
+	return fmt.Sprintf(format, n)
+}
+
+// formatDay formats the day of t, such as "Mon 12 Oct".
+func (loc *locale) formatDay(t time.Time) string {
+	return fmt.Sprintf("%s %d %s", loc.Weekdays[t.Weekday()], t.Day(), loc.Months[t.Month()-1])
+}
+
+// formatDate formats the date of t, such as "Mon 12 Oct 2026".
+func (loc *locale) formatDate(t time.Time) string {
+	return fmt.Sprintf("%s %d", loc.formatDay(t), t.Year())
+}
+
+// dayHeader names the day of t relative to now, both in the same location:
+// Today, Yesterday, the day such as "Mon 12 Oct" in the current year, or
+// the date in earlier years.
+func (loc *locale) dayHeader(t, now time.Time) string {
+	y, m, d := t.Date()
+	if ny, nm, nd := now.Date(); y == ny && m == nm && d == nd {
+		return loc.Today
+	}
+	if yy, ym, yd := now.AddDate(0, 0, -1).Date(); y == yy && m == ym && d == yd {
+		return loc.Yesterday
+	}
+	if y == now.Year() {
+		return loc.formatDay(t)
+	}
+	return loc.formatDate(t)
+}
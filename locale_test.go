@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveLocale(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+
+	t.Run("Successfully validates locale from flag", func(t *testing.T) {
+		loc, err := resolveLocale("fr_FR.UTF-8", env(map[string]string{"LANG": "en_US.UTF-8"}))
+		require.Nil(t, err)
+		require.Equal(t, "fr", loc.Tag)
+	})
+
+	t.Run("Successfully validates LC_ALL takes precedence over LANG", func(t *testing.T) {
+		loc, err := resolveLocale("", env(map[string]string{"LC_ALL": "fr_CA.UTF-8", "LANG": "en_GB.UTF-8"}))
+		require.Nil(t, err)
+		require.Equal(t, "fr", loc.Tag)
+	})
+
+	t.Run("Successfully validates English for C and unsupported locales", func(t *testing.T) {
+		for _, lang := range []string{"", "C", "POSIX", "de_DE.UTF-8"} {
+			loc, err := resolveLocale("", env(map[string]string{"LANG": lang}))
+			require.Nil(t, err)
+			require.Equal(t, "en", loc.Tag)
+		}
+	})
+
+	t.Run("Successfully validates error for unsupported flag locale", func(t *testing.T) {
+		_, err := resolveLocale("de", env(nil))
+		require.EqualError(t, err, `unsupported locale "de", expected one of en, fr`)
+		require.Equal(t, exitUsage, exitCode(err))
+	})
+}
+
+func TestPluralForm(t *testing.T) {
+	t.Run("Successfully validates English plural rules", func(t *testing.T) {
+		require.Equal(t, "commits", englishLocale.pluralForm(0, "commit", "commits"))
+		require.Equal(t, "commit", englishLocale.pluralForm(1, "commit", "commits"))
+		require.Equal(t, "commits", englishLocale.pluralForm(2, "one:commit", "other:commits"))
+	})
+
+	t.Run("Successfully validates French plural rules", func(t *testing.T) {
+		require.Equal(t, "commit", frenchLocale.pluralForm(0, "one:commit", "other:commits"))
+		require.Equal(t, "commit", frenchLocale.pluralForm(1, "one:commit", "other:commits"))
+		require.Equal(t, "commits", frenchLocale.pluralForm(2, "one:commit", "other:commits"))
+		require.Equal(t, "de commits", frenchLocale.pluralForm(1000000, "one:commit", "many:de commits", "other:commits"))
+		require.Equal(t, "commits", frenchLocale.pluralForm(2000000, "one:commit", "other:commits"))
+	})
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Successfully validates relative times", func(t *testing.T) {
+		require.Equal(t, "just now", englishLocale.relativeTime(now.Add(-30*time.Second), now))
+		require.Equal(t, "1 minute ago", englishLocale.relativeTime(now.Add(-time.Minute), now))
+		require.Equal(t, "3 hours ago", englishLocale.relativeTime(now.Add(-3*time.Hour), now))
+		require.Equal(t, "2 days ago", englishLocale.relativeTime(now.Add(-50*time.Hour), now))
+		require.Equal(t, "1 year ago", englishLocale.relativeTime(now.AddDate(-1, 0, -1), now))
+	})
+
+	t.Run("Successfully validates French relative times", func(t *testing.T) {
+		require.Equal(t, "à l'instant", frenchLocale.relativeTime(now.Add(-30*time.Second), now))
+		require.Equal(t, "il y a 1 heure", frenchLocale.relativeTime(now.Add(-time.Hour), now))
+		require.Equal(t, "il y a 3 jours", frenchLocale.relativeTime(now.Add(-72*time.Hour), now))
+	})
+}
+
+func TestFormatDate(t *testing.T) {
+	day := time.Date(2026, 10, 12, 9, 30, 0, 0, time.UTC)
+
+	t.Run("Successfully validates English date", func(t *testing.T) {
+		require.Equal(t, "Mon 12 Oct 2026", englishLocale.formatDate(day))
+	})
+
+	t.Run("Successfully validates French date", func(t *testing.T) {
+		require.Equal(t, "lun. 12 oct. 2026", frenchLocale.formatDate(day))
+	})
+}
+
+func TestDayHeader(t *testing.T) {
+	now := time.Date(2026, 10, 17, 0, 30, 0, 0, time.UTC)
+
+	t.Run("Successfully validates day headers", func(t *testing.T) {
+		require.Equal(t, "Today", englishLocale.dayHeader(now.Add(-20*time.Minute), now))
+		require.Equal(t, "Yesterday", englishLocale.dayHeader(now.Add(-time.Hour), now))
+		require.Equal(t, "Mon 12 Oct", englishLocale.dayHeader(time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC), now))
+		require.Equal(t, "Thu 28 Nov 2024", englishLocale.dayHeader(time.Date(2024, 11, 28, 9, 0, 0, 0, time.UTC), now))
+		require.Equal(t, "Hier", frenchLocale.dayHeader(now.Add(-time.Hour), now))
+	})
+}
+
+func TestFrenchMessages(t *testing.T) {
+	t.Run("Successfully validates every French message parses", func(t *testing.T) {
+		for key := range frenchMessages {
+			_, ok := defaultMessages[key]
+			require.True(t, ok, "unknown message %s", key)
+		}
+		_, err := newMessageCatalog(frenchLocale, time.Local, nil)
+		require.Nil(t, err)
+	})
+
+	t.Run("Successfully validates French push message", func(t *testing.T) {
+		mc, err := newMessageCatalog(frenchLocale, time.Local, nil)
+		require.Nil(t, err)
+		previous := messages
+		messages = mc
+		t.Cleanup(func() { messages = previous })
+
+		s, err := parsePushEvent(json.RawMessage(`{"size": 1}`), "devUser/awesome-project")
+		require.Nil(t, err)
+		require.Equal(t, "1 commit poussé vers devUser/awesome-project", s)
+		s, err = parsePushEvent(json.RawMessage(`{"size": 3}`), "devUser/awesome-project")
+		require.Nil(t, err)
+		require.Equal(t, "3 commits poussés vers devUser/awesome-project", s)
+	})
+}
+
+func TestRunLocale(t *testing.T) {
+	t.Run("Successfully validates locale from LANG", func(t *testing.T) {
+		setupRun(t, newTestServer(t, []string{"[" + pushEventJSON + "]"}))
+		t.Setenv("LANG", "fr_FR.UTF-8")
+		t.Cleanup(func() { messages = mustMessageCatalog(englishLocale, time.Local, nil) })
+
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--time", "iso", "--tz", "UTC", "devUser"}, &stdout, &stderr)
+		require.Nil(t, err)
+		require.Equal(t, "2024-11-28T14:05:00Z  2 commits poussés vers main dans devUser/awesome-project\n", stdout.String())
+	})
+
+	t.Run("Successfully validates --locale overrides LANG", func(t *testing.T) {
+		setupRun(t, newTestServer(t, []string{"[" + pushEventJSON + "]"}))
+		t.Setenv("LANG", "fr_FR.UTF-8")
+		t.Cleanup(func() { messages = mustMessageCatalog(englishLocale, time.Local, nil) })
+
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--locale", "en", "--time", "iso", "--tz", "UTC", "devUser"}, &stdout, &stderr)
+		require.Nil(t, err)
+		require.Equal(t, "2024-11-28T14:05:00Z  Pushed 2 commits to main in devUser/awesome-project\n", stdout.String())
+	})
+}
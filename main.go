@@ -2,32 +2,61 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"net/http"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 )
 
-type Event []struct {
-	Type string `json:"type"`
+type Event []RawEvent
+
+type RawEvent struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Actor struct {
+		Login string `json:"login"`
+	} `json:"actor"`
 	Repo struct {
 		Name string `json:"name"`
 	} `json:"repo"`
-	Payload json.RawMessage `json:"payload"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+	// Raw is the event as returned by the API, for --select.
+	Raw json.RawMessage `json:"-"`
+}
+
+func (e *RawEvent) UnmarshalJSON(b []byte) error {
+	type rawEvent RawEvent
+	if err := json.Unmarshal(b, (*rawEvent)(e)); err != nil {
+		return err
+	}
+	e.Raw = append(json.RawMessage(nil), b...)
+	return nil
 }
 
 type CreateEvent struct {
-	RefType string `json:"ref_type"`
+	// Ref is the name of the branch or tag, null for repositories.
+	Ref          string `json:"ref"`
+	RefType      string `json:"ref_type"`
+	MasterBranch string `json:"master_branch"`
+	Description  string `json:"description"`
 }
 
 type DeleteEvent struct {
+	Ref     string `json:"ref"`
 	RefType string `json:"ref_type"`
 }
 
 type IssuesEvent struct {
 	Action string `json:"action"`
 	Issue  struct {
-		Number int    `json:"number"`
-		Title  string `json:"title"`
+		Number           int    `json:"number"`
+		Title            string `json:"title"`
+		ActiveLockReason string `json:"active_lock_reason"`
 	} `json:"issue"`
 	Assignee struct {
 		Login string `json:"login"`
@@ -35,24 +64,242 @@ type IssuesEvent struct {
 	Label struct {
 		Name string `json:"name"`
 	} `json:"label"`
+	Milestone struct {
+		Title string `json:"title"`
+	} `json:"milestone"`
+	IssueType struct {
+		Name string `json:"name"`
+	} `json:"type"`
+	Changes struct {
+		NewRepository struct {
+			FullName string `json:"full_name"`
+		} `json:"new_repository"`
+		NewIssue struct {
+			Url string `json:"html_url"`
+		} `json:"new_issue"`
+	} `json:"changes"`
 }
 
 type PullRequestEvent struct {
 	Action      string `json:"action"`
 	Number      int    `json:"number"`
 	PullRequest struct {
-		Url   string `json:"url"`
-		Title string `json:"title"`
+		Url      string     `json:"url"`
+		HtmlUrl  string     `json:"html_url"`
+		Title    string     `json:"title"`
+		Merged   bool       `json:"merged"`
+		MergedAt *time.Time `json:"merged_at"`
+		Draft    bool       `json:"draft"`
+		Base     struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+		Head struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+		Labels []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+		RequestedReviewers []struct {
+			Login string `json:"login"`
+		} `json:"requested_reviewers"`
+		Milestone struct {
+			Title string `json:"title"`
+		} `json:"milestone"`
 	} `json:"pull_request"`
 	Assignee struct {
 		Login string `json:"login"`
 	} `json:"assignee"`
+	Label struct {
+		Name string `json:"name"`
+	} `json:"label"`
+	RequestedReviewer struct {
+		Login string `json:"login"`
+	} `json:"requested_reviewer"`
+	RequestedTeam struct {
+		Name string `json:"name"`
+	} `json:"requested_team"`
+	// Reason explains auto_merge_disabled and dequeued actions.
+	Reason string `json:"reason"`
+}
+
+// IsMerged reports whether the pull request is merged, payloads may only
+// carry merged_at.
+func (e PullRequestEvent) IsMerged() bool {
+	return e.PullRequest.Merged || e.PullRequest.MergedAt != nil
 }
 
 type PushEvent struct {
-	Size int `json:"size"`
+	Ref    string `json:"ref"`
+	Before string `json:"before"`
+	Head   string `json:"head"`
+	// Size, DistinctSize and Commits are nil when the payload leaves them
+	// out, as the current Events API does.
+	Size         *int         `json:"size"`
+	DistinctSize *int         `json:"distinct_size"`
+	Commits      []PushCommit `json:"commits"`
+}
+
+type PushCommit struct {
+	Sha     string `json:"sha"`
+	Message string `json:"message"`
+	Author  struct {
+		Name string `json:"name"`
+	} `json:"author"`
+	Distinct bool   `json:"distinct"`
+	Url      string `json:"url"`
+}
+
+// zeroSHA is the before SHA of pushes creating a branch and the head SHA of
+// pushes deleting one.
+const zeroSHA = "0000000000000000000000000000000000000000"
+
+// Branch returns the pushed branch without its refs/heads/ prefix, empty
+// for pushes to a tag.
+func (p PushEvent) Branch() string {
+	if p.Tag() != "" {
+		return ""
+	}
+	return strings.TrimPrefix(p.Ref, "refs/heads/")
+}
+
+// Tag returns the pushed tag without its refs/tags/ prefix, empty for
+// pushes to a branch.
+func (p PushEvent) Tag() string {
+	tag, _ := strings.CutPrefix(p.Ref, "refs/tags/")
+	if tag == p.Ref {
+		return ""
+	}
+	return tag
 }
 
+// Existing returns the number of pushed commits already in the repository,
+// such as the commits of a merged branch.
+func (p PushEvent) Existing() int {
+	if p.Size == nil || p.DistinctSize == nil {
+		return 0
+	}
+	return *p.Size - *p.DistinctSize
+}
+
+// withoutCommits reports whether the payload says no commit was pushed,
+// payloads leaving size out say nothing.
+func (p PushEvent) withoutCommits() bool {
+	return p.Size != nil && *p.Size == 0 && len(p.Commits) == 0
+}
+
+// Forced reports whether the push looks like a force-push or a branch reset:
+// the branch moved to another commit without pushing any commit.
+func (p PushEvent) Forced() bool {
+	return p.withoutCommits() && p.Before != p.Head &&
+		p.Before != "" && p.Before != zeroSHA &&
+		p.Head != "" && p.Head != zeroSHA
+}
+
+// action returns the message action of the push: deleted for pushes
+// deleting the ref, created for pushes creating it without commits, forced
+// for force-pushes and empty otherwise.
+func (p PushEvent) action() string {
+	switch {
+	case p.Head == zeroSHA:
+		return "deleted"
+	case p.Before == zeroSHA && (p.Size == nil || p.withoutCommits()):
+		return "created"
+	case p.Forced():
+		return "forced"
+	}
+	return ""
+}
+
+type IssueCommentEvent struct {
+	Action string `json:"action"`
+	Issue  struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	} `json:"issue"`
+	Comment struct {
+		Body string `json:"body"`
+		Url  string `json:"html_url"`
+	} `json:"comment"`
+}
+
+type CommitCommentEvent struct {
+	Action  string `json:"action"`
+	Comment struct {
+		CommitID string `json:"commit_id"`
+		Body     string `json:"body"`
+		Url      string `json:"html_url"`
+	} `json:"comment"`
+}
+
+type PullRequestReviewCommentEvent struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Url    string `json:"html_url"`
+	} `json:"pull_request"`
+	Comment struct {
+		Body string `json:"body"`
+		Url  string `json:"html_url"`
+	} `json:"comment"`
+}
+
+type PullRequestReviewEvent struct {
+	Action string `json:"action"`
+	Review struct {
+		State string `json:"state"`
+		Url   string `json:"html_url"`
+	} `json:"review"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Url    string `json:"html_url"`
+	} `json:"pull_request"`
+}
+
+type GollumEvent struct {
+	Pages []GollumPage `json:"pages"`
+}
+
+type GollumPage struct {
+	PageName string `json:"page_name"`
+	Title    string `json:"title"`
+	Action   string `json:"action"`
+	Url      string `json:"html_url"`
+}
+
+type MemberEvent struct {
+	Action string `json:"action"`
+	Member struct {
+		Login string `json:"login"`
+		Url   string `json:"html_url"`
+	} `json:"member"`
+	Changes struct {
+		Permission struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"permission"`
+		OldPermission struct {
+			From string `json:"from"`
+		} `json:"old_permission"`
+	} `json:"changes"`
+}
+
+type ForkEvent struct {
+	Forkee struct {
+		FullName string `json:"full_name"`
+		Url      string `json:"html_url"`
+	} `json:"forkee"`
+}
+
+type WatchEvent struct {
+	Action string `json:"action"`
+}
+
+// PublicEvent is sent when a private repository is made public, its payload
+// is empty.
+type PublicEvent struct{}
+
 type ReleaseEvent struct {
 	Action  string `json:"action"`
 	Release struct {
@@ -61,197 +308,593 @@ type ReleaseEvent struct {
 	} `json:"release"`
 }
 
-func checkStatusCode(statusCode int) (string, error) {
-	var s string
-	if statusCode == 200 || statusCode == 304 {
-		s = "Success"
-	} else if statusCode == 403 {
-		return "", fmt.Errorf("forbidden")
-	} else if statusCode == 404 {
-		return "", fmt.Errorf("not found")
-	} else if statusCode == 503 {
-		return "", fmt.Errorf("service unavailable")
-	}
-	return s, nil
-}
-
 func parseCreateEvent(payload json.RawMessage, reponame string) (string, error) {
-	var s string
 	var cresp CreateEvent
 	if err := json.Unmarshal(payload, &cresp); err != nil {
-		panic(err)
+		return "", &DecodeError{What: "CreateEvent payload", Err: err}
 	}
 
-	if cresp.RefType == "repository" {
-		s = fmt.Sprintf("Created new repository %s", reponame)
-	} else if cresp.RefType == "branch" {
-		s = fmt.Sprintf("Created new branch %s", reponame)
-	} else if cresp.RefType == "tag" {
-		s = fmt.Sprintf("Created new tag %s", reponame)
-	} else {
-		return "", fmt.Errorf("unable to parse, reference type is empty")
+	if cresp.RefType == "" || !messages.has("CreateEvent", cresp.RefType) {
+		return "", &UnsupportedEventError{Type: "CreateEvent", Reason: "reference type is empty"}
 	}
 
-	return s, nil
+	return messages.render("CreateEvent", cresp.RefType, struct {
+		CreateEvent
+		Repo string
+	}{cresp, reponame})
 }
 
 func parseDeleteEvent(payload json.RawMessage, reponame string) (string, error) {
-	var s string
 	var cresp DeleteEvent
 	if err := json.Unmarshal(payload, &cresp); err != nil {
-		panic(err)
+		return "", &DecodeError{What: "DeleteEvent payload", Err: err}
 	}
 
-	if cresp.RefType == "branch" {
-		s = fmt.Sprintf("Deleted branch %s\n", reponame)
-	} else if cresp.RefType == "tag" {
-		s = fmt.Sprintf("Deleted tag %s\n", reponame)
-	} else {
-		return "", fmt.Errorf("unable to parse, reference type is empty")
+	if cresp.RefType == "" || !messages.has("DeleteEvent", cresp.RefType) {
+		return "", &UnsupportedEventError{Type: "DeleteEvent", Reason: "reference type is empty"}
 	}
 
-	return s, nil
+	return messages.render("DeleteEvent", cresp.RefType, struct {
+		DeleteEvent
+		Repo string
+	}{cresp, reponame})
 }
 
 func parseIssuesEvent(payload json.RawMessage, reponame string) (string, error) {
-	var s string
 	var cresp IssuesEvent
 	if err := json.Unmarshal(payload, &cresp); err != nil {
-		panic(err)
+		return "", &DecodeError{What: "IssuesEvent payload", Err: err}
 	}
 
-	if cresp.Action == "opened" {
-		s = fmt.Sprintf("Issue %d. %s for %s is opened", cresp.Issue.Number, cresp.Issue.Title, reponame)
-	} else if cresp.Action == "edited" {
-		s = fmt.Sprintf("Issue %d. %s for %s is edited", cresp.Issue.Number, cresp.Issue.Title, reponame)
-	} else if cresp.Action == "closed" {
-		s = fmt.Sprintf("Issue %d. %s for %s is closed", cresp.Issue.Number, cresp.Issue.Title, reponame)
-	} else if cresp.Action == "reopened" {
-		s = fmt.Sprintf("Issue %d. %s for %s is reopened", cresp.Issue.Number, cresp.Issue.Title, reponame)
-	} else if cresp.Action == "assigned" {
-		s = fmt.Sprintf("Issue %d. %s for %s is assigned to %s", cresp.Issue.Number, cresp.Issue.Title, reponame, cresp.Assignee.Login)
-	} else if cresp.Action == "unassigned" {
-		s = fmt.Sprintf("Issue %d. %s for %s is unassigned from %s", cresp.Issue.Number, cresp.Issue.Title, reponame, cresp.Assignee.Login)
-	} else if cresp.Action == "labeled" {
-		s = fmt.Sprintf("Issue %d. %s for %s is labeled as %s", cresp.Issue.Number, cresp.Issue.Title, reponame, cresp.Label.Name)
-	} else if cresp.Action == "unlabeled" {
-		s = fmt.Sprintf("Issue %d. %s for %s is unlabeled from %s", cresp.Issue.Number, cresp.Issue.Title, reponame, cresp.Label.Name)
-	} else {
-		return "", fmt.Errorf("unable to parse")
+	if cresp.Action == "" {
+		return "", &UnsupportedEventError{Type: "IssuesEvent"}
 	}
 
-	return s, nil
+	return messages.render("IssuesEvent", cresp.Action, struct {
+		IssuesEvent
+		Repo string
+	}{cresp, reponame})
 }
 
 func parsePullRequestEvent(payload json.RawMessage, reponame string) (string, error) {
-	var s string
 	var cresp PullRequestEvent
 	if err := json.Unmarshal(payload, &cresp); err != nil {
-		panic(err)
+		return "", &DecodeError{What: "PullRequestEvent payload", Err: err}
 	}
 
-	if cresp.Action == "opened" {
-		s = fmt.Sprintf("Pull request %d. %s for %s is opened at %s", cresp.Number, cresp.PullRequest.Title, reponame, cresp.PullRequest.Url)
-	} else if cresp.Action == "closed" {
-		s = fmt.Sprintf("Pull request %d. %s for %s is closed at %s", cresp.Number, cresp.PullRequest.Title, reponame, cresp.PullRequest.Url)
-	} else if cresp.Action == "reopened" {
-		s = fmt.Sprintf("Pull request %d. %s for %s is reopened at %s", cresp.Number, cresp.PullRequest.Title, reponame, cresp.PullRequest.Url)
-	} else if cresp.Action == "assigned" {
-		s = fmt.Sprintf("Pull request %d. %s for %s is assigned to %s, %s", cresp.Number, cresp.PullRequest.Title, reponame, cresp.Assignee.Login, cresp.PullRequest.Url)
-	} else if cresp.Action == "synchronize" {
-		s = fmt.Sprintf("Pull request %d. %s for %s is synchronized, %s", cresp.Number, cresp.PullRequest.Title, reponame, cresp.PullRequest.Url)
-	} else {
-		return "", fmt.Errorf("unable to parse")
+	if cresp.Action == "" {
+		return "", &UnsupportedEventError{Type: "PullRequestEvent"}
 	}
 
-	return s, nil
+	return messages.render("PullRequestEvent", cresp.Action, struct {
+		PullRequestEvent
+		Repo string
+	}{cresp, reponame})
 }
 
 func parsePushEvent(payload json.RawMessage, reponame string) (string, error) {
-	var s string
 	var cresp PushEvent
 	if err := json.Unmarshal(payload, &cresp); err != nil {
-		panic(err)
+		return "", &DecodeError{What: "PushEvent payload", Err: err}
+	}
+
+	if cresp.Head == "" && (cresp.Size == nil || *cresp.Size < 1) {
+		return "", &UnsupportedEventError{Type: "PushEvent", Reason: "push is empty"}
 	}
 
-	if cresp.Size == 1 {
-		s = fmt.Sprintf("Pushed %d commit to %s", cresp.Size, reponame)
-	} else if cresp.Size > 1 {
-		s = fmt.Sprintf("Pushed %d commits to %s", cresp.Size, reponame)
-	} else {
-		return "", fmt.Errorf("unable to parse")
+	return messages.render("PushEvent", cresp.action(), struct {
+		PushEvent
+		Repo string
+	}{cresp, reponame})
+}
+
+func parseIssueCommentEvent(payload json.RawMessage, reponame string) (string, error) {
+	var cresp IssueCommentEvent
+	if err := json.Unmarshal(payload, &cresp); err != nil {
+		return "", &DecodeError{What: "IssueCommentEvent payload", Err: err}
 	}
 
-	return s, nil
+	if cresp.Action == "" {
+		return "", &UnsupportedEventError{Type: "IssueCommentEvent"}
+	}
 
+	return messages.render("IssueCommentEvent", cresp.Action, struct {
+		IssueCommentEvent
+		Repo string
+	}{cresp, reponame})
 }
 
-func parseReleaseEvent(payload json.RawMessage) (string, error) {
-	var s string
+func parseCommitCommentEvent(payload json.RawMessage, reponame string) (string, error) {
+	var cresp CommitCommentEvent
+	if err := json.Unmarshal(payload, &cresp); err != nil {
+		return "", &DecodeError{What: "CommitCommentEvent payload", Err: err}
+	}
+
+	// Older events have no action, comments on commits could only be created.
+	if cresp.Action == "" {
+		cresp.Action = "created"
+	}
+	if cresp.Comment.CommitID == "" {
+		return "", &UnsupportedEventError{Type: "CommitCommentEvent", Reason: "commit is empty"}
+	}
+
+	return messages.render("CommitCommentEvent", cresp.Action, struct {
+		CommitCommentEvent
+		Repo string
+	}{cresp, reponame})
+}
+
+func parsePullRequestReviewCommentEvent(payload json.RawMessage, reponame string) (string, error) {
+	var cresp PullRequestReviewCommentEvent
+	if err := json.Unmarshal(payload, &cresp); err != nil {
+		return "", &DecodeError{What: "PullRequestReviewCommentEvent payload", Err: err}
+	}
+
+	if cresp.Action == "" {
+		return "", &UnsupportedEventError{Type: "PullRequestReviewCommentEvent"}
+	}
+
+	return messages.render("PullRequestReviewCommentEvent", cresp.Action, struct {
+		PullRequestReviewCommentEvent
+		Repo string
+	}{cresp, reponame})
+}
+
+// parsePullRequestReviewEvent renders a review by its state, such as
+// approved or changes_requested, rather than by its action.
+func parsePullRequestReviewEvent(payload json.RawMessage, reponame string) (string, error) {
+	var cresp PullRequestReviewEvent
+	if err := json.Unmarshal(payload, &cresp); err != nil {
+		return "", &DecodeError{What: "PullRequestReviewEvent payload", Err: err}
+	}
+
+	cresp.Review.State = strings.ToLower(cresp.Review.State)
+	if cresp.Review.State == "" || !messages.has("PullRequestReviewEvent", cresp.Review.State) {
+		return "", &UnsupportedEventError{Type: "PullRequestReviewEvent", Reason: "review state is empty"}
+	}
+
+	return messages.render("PullRequestReviewEvent", cresp.Review.State, struct {
+		PullRequestReviewEvent
+		Repo string
+	}{cresp, reponame})
+}
+
+// parseGollumEvent renders every page of a wiki update on a line of its own.
+func parseGollumEvent(payload json.RawMessage, reponame string) (string, error) {
+	var cresp GollumEvent
+	if err := json.Unmarshal(payload, &cresp); err != nil {
+		return "", &DecodeError{What: "GollumEvent payload", Err: err}
+	}
+
+	if len(cresp.Pages) == 0 {
+		return "", &UnsupportedEventError{Type: "GollumEvent", Reason: "no pages"}
+	}
+
+	lines := make([]string, len(cresp.Pages))
+	for i, page := range cresp.Pages {
+		if page.Action == "" {
+			return "", &UnsupportedEventError{Type: "GollumEvent"}
+		}
+		line, err := messages.render("GollumEvent", page.Action, struct {
+			GollumPage
+			Repo string
+		}{page, reponame})
+		if err != nil {
+			return "", err
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func parseMemberEvent(payload json.RawMessage, reponame string) (string, error) {
+	var cresp MemberEvent
+	if err := json.Unmarshal(payload, &cresp); err != nil {
+		return "", &DecodeError{What: "MemberEvent payload", Err: err}
+	}
+
+	if cresp.Action == "" {
+		return "", &UnsupportedEventError{Type: "MemberEvent"}
+	}
+	// Some payloads only carry the previous permission as old_permission.
+	if cresp.Changes.Permission.From == "" {
+		cresp.Changes.Permission.From = cresp.Changes.OldPermission.From
+	}
+
+	return messages.render("MemberEvent", cresp.Action, struct {
+		MemberEvent
+		Repo string
+	}{cresp, reponame})
+}
+
+func parseForkEvent(payload json.RawMessage, reponame string) (string, error) {
+	var cresp ForkEvent
+	if err := json.Unmarshal(payload, &cresp); err != nil {
+		return "", &DecodeError{What: "ForkEvent payload", Err: err}
+	}
+
+	if cresp.Forkee.FullName == "" {
+		return "", &UnsupportedEventError{Type: "ForkEvent", Reason: "fork repository is empty"}
+	}
+
+	return messages.render("ForkEvent", "", struct {
+		ForkEvent
+		Repo string
+	}{cresp, reponame})
+}
+
+func parseWatchEvent(payload json.RawMessage, reponame string) (string, error) {
+	var cresp WatchEvent
+	if err := json.Unmarshal(payload, &cresp); err != nil {
+		return "", &DecodeError{What: "WatchEvent payload", Err: err}
+	}
+
+	if cresp.Action == "" {
+		return "", &UnsupportedEventError{Type: "WatchEvent"}
+	}
+
+	return messages.render("WatchEvent", cresp.Action, struct {
+		WatchEvent
+		Repo string
+	}{cresp, reponame})
+}
+
+func parsePublicEvent(reponame string) (string, error) {
+	return messages.render("PublicEvent", "", struct {
+		PublicEvent
+		Repo string
+	}{PublicEvent{}, reponame})
+}
+
+func parseReleaseEvent(payload json.RawMessage, reponame string) (string, error) {
 	var cresp ReleaseEvent
 	if err := json.Unmarshal(payload, &cresp); err != nil {
-		panic(err)
+		return "", &DecodeError{What: "ReleaseEvent payload", Err: err}
 	}
 
-	if cresp.Action == "published" {
-		s = fmt.Sprintf("%s published at %s", cresp.Release.Name, cresp.Release.Url)
-	} else if cresp.Action == "prereleased" {
-		s = fmt.Sprintf("%s prereleased at %s", cresp.Release.Name, cresp.Release.Url)
-	} else if cresp.Action == "created" {
-		s = fmt.Sprintf("%s created at %s", cresp.Release.Name, cresp.Release.Url)
-	} else {
-		return "", fmt.Errorf("unable to parse")
+	if cresp.Action == "" {
+		return "", &UnsupportedEventError{Type: "ReleaseEvent"}
 	}
 
-	return s, nil
+	return messages.render("ReleaseEvent", cresp.Action, struct {
+		ReleaseEvent
+		Repo string
+	}{cresp, reponame})
 }
 
-func main() {
-	username := os.Args[1]
+// parseEvent renders a single event, returning an empty string for event
+// types that aren't supported.
+func parseEvent(eventType string, payload json.RawMessage, reponame string) (string, error) {
+	var s string
+	var err error
+
+	if eventType == "CreateEvent" {
+		s, err = parseCreateEvent(payload, reponame)
+	} else if eventType == "DeleteEvent" {
+		s, err = parseDeleteEvent(payload, reponame)
+	} else if eventType == "IssuesEvent" {
+		s, err = parseIssuesEvent(payload, reponame)
+	} else if eventType == "PullRequestEvent" {
+		s, err = parsePullRequestEvent(payload, reponame)
+	} else if eventType == "PushEvent" {
+		s, err = parsePushEvent(payload, reponame)
+	} else if eventType == "ReleaseEvent" {
+		s, err = parseReleaseEvent(payload, reponame)
+	} else if eventType == "ForkEvent" {
+		s, err = parseForkEvent(payload, reponame)
+	} else if eventType == "WatchEvent" {
+		s, err = parseWatchEvent(payload, reponame)
+	} else if eventType == "PublicEvent" {
+		s, err = parsePublicEvent(reponame)
+	} else if eventType == "IssueCommentEvent" {
+		s, err = parseIssueCommentEvent(payload, reponame)
+	} else if eventType == "CommitCommentEvent" {
+		s, err = parseCommitCommentEvent(payload, reponame)
+	} else if eventType == "GollumEvent" {
+		s, err = parseGollumEvent(payload, reponame)
+	} else if eventType == "MemberEvent" {
+		s, err = parseMemberEvent(payload, reponame)
+	} else if eventType == "PullRequestReviewEvent" {
+		s, err = parsePullRequestReviewEvent(payload, reponame)
+	} else if eventType == "PullRequestReviewCommentEvent" {
+		s, err = parsePullRequestReviewCommentEvent(payload, reponame)
+	}
 
-	url := "https://api.github.com/users/" + username + "/events"
+	return s, err
+}
 
-	resp, err := http.Get(url)
+// fallbackEvent renders an event that couldn't be parsed.
+func fallbackEvent(eventType string, reponame string) string {
+	return fmt.Sprintf("%s in %s", eventType, reponame)
+}
+
+type skippedEvent struct {
+	Type string
+	Repo string
+	Err  error
+}
+
+// printSkipped writes a summary of the events rendered with fallbackEvent.
+func printSkipped(w io.Writer, skipped []skippedEvent) {
+	if len(skipped) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "skipped %d events that could not be parsed:\n", len(skipped))
+	for _, event := range skipped {
+		fmt.Fprintf(w, "  %s in %s: %s\n", event.Type, event.Repo, event.Err)
+	}
+}
+
+// clientFlags are the flags shared by every command that talks to the API.
+type clientFlags struct {
+	token           *string
+	tokenFile       *string
+	debug           *bool
+	waitOnRateLimit *bool
+	maxAttempts     *int
+	retryDeadline   *time.Duration
+	noCache         *bool
+}
+
+func addClientFlags(fs *flag.FlagSet) *clientFlags {
+	return &clientFlags{
+		token:           fs.String("token", "", "personal access token used to authenticate requests"),
+		tokenFile:       fs.String("token-file", "", "file containing the personal access token"),
+		debug:           fs.Bool("debug", false, "log requests to stderr"),
+		waitOnRateLimit: fs.Bool("wait-on-ratelimit", false, "wait for the rate limit to reset instead of failing"),
+		maxAttempts:     fs.Int("max-attempts", defaultRetryPolicy.MaxAttempts, "maximum number of attempts for requests failing with transient errors"),
+		retryDeadline:   fs.Duration("retry-deadline", defaultRetryPolicy.Deadline, "maximum time spent on a request, retries included"),
+		noCache:         fs.Bool("no-cache", false, "don't read or write the response cache"),
+	}
+}
+
+// newClient builds a client from the flags, resolving the token and
+// writing warnings and debug logs to stderr.
+func (f *clientFlags) newClient(stderr io.Writer) (*client, error) {
+	token, err := resolveToken(*f.token, *f.tokenFile, os.Getenv)
 	if err != nil {
-		panic(err)
+		return nil, redactError(err, token)
+	}
+
+	c := newClient(token)
+	if u := os.Getenv("GITHUB_API_URL"); u != "" {
+		c.baseURL = strings.TrimSuffix(u, "/")
+	}
+	c.warnings = stderr
+	if *f.debug {
+		c.debug = stderr
 	}
+	c.waitOnRateLimit = *f.waitOnRateLimit
+	c.retry.MaxAttempts = *f.maxAttempts
+	c.retry.Deadline = *f.retryDeadline
+	if !*f.noCache {
+		c.cache = &httpCache{dir: cacheDir(os.Getenv)}
+	}
+	return c, nil
+}
 
-	defer resp.Body.Close()
+// parseFlags parses args into fs, turning parse failures into usage errors.
+// flag.ErrHelp is returned as is when help is requested.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &UsageError{Message: err.Error()}
+	}
+	return nil
+}
 
-	_, err = checkStatusCode(resp.StatusCode)
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "ratelimit":
+			return runRateLimit(args[1:], stdout, stderr)
+		case "cache":
+			return runCache(args[1:], stdout, stderr)
+		}
+	}
 
+	fs := flag.NewFlagSet("github-activity", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: github-activity [flags] USER_NAME")
+		fs.PrintDefaults()
+	}
+	cf := addClientFlags(fs)
+	maxPages := fs.Int("max-pages", defaultMaxPages, "maximum number of pages of events to fetch")
+	limit := fs.Int("limit", 0, "maximum number of events to print, 0 prints every fetched event")
+	types := fs.String("type", "", "comma separated event types to print, such as PushEvent,PullRequestEvent")
+	actions := fs.String("action", "", "comma separated event actions to print, such as opened,closed")
+	repos := fs.String("repo", "", "comma separated repository globs to print, such as 'org/*'")
+	excludeRepos := fs.String("exclude-repo", "", "comma separated repository globs to leave out")
+	since := fs.String("since", "", "print events from this time on: a duration ago such as 7d, a date or an RFC 3339 time")
+	whereSrc := fs.String("where", "", "print the events matching an expression, such as 'type == \"PushEvent\" && payload.size > 1'")
+	until := fs.String("until", "", "print events before this time: a duration ago, a date (included) or an RFC 3339 time")
+	strict := fs.Bool("strict", false, "stop at the first event that can't be parsed")
+	output := fs.String("output", "text", "output format: text, json, ndjson, csv or tsv")
+	columns := fs.String("columns", "", "comma separated CSV and TSV columns, defaults to "+strings.Join(csvColumns, ","))
+	selectPath := fs.String("select", "", "print the values of a path such as 'payload.commits[*].message' instead of the events")
+	templateFile := fs.String("template", "", "render the events with the Go text/template in `FILE`")
+	format := fs.String("format", "", "render the events with an inline Go text/template")
+	configFile := fs.String("config", "", "config file, defaults to $XDG_CONFIG_HOME/github-activity/config.yml")
+	timeFormat := fs.String("time", "relative", "timestamps of the text output: relative, absolute or iso")
+	tz := fs.String("tz", "", "time zone of the timestamps, such as Europe/Paris, defaults to the local time zone")
+	localeName := fs.String("locale", "", "language of the messages, defaults to $LC_ALL, $LC_MESSAGES or $LANG")
+	commits := fs.Bool("commits", false, "list the commits of every push under it in the text output")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return &UsageError{Message: "expected exactly one USER_NAME argument"}
+	}
+	username := fs.Arg(0)
+
+	path := *configFile
+	if path == "" {
+		path = configPath(os.Getenv)
+	}
+	cfg, err := loadConfig(path, *configFile != "")
 	if err != nil {
-		panic(err)
+		return err
+	}
+	loc, err := resolveLocale(*localeName, os.Getenv)
+	if err != nil {
+		return err
+	}
+
+	location := time.Local
+	if *tz != "" {
+		if location, err = time.LoadLocation(*tz); err != nil {
+			return &UsageError{Message: fmt.Sprintf("unknown time zone %q", *tz)}
+		}
+	}
+	if messages, err = newMessageCatalog(loc, location, cfg.Messages); err != nil {
+		return err
 	}
 
-	var cresp Event
-	if err := json.NewDecoder(resp.Body).Decode(&cresp); err != nil {
-		panic(err)
+	now := time.Now()
+	filter := eventFilter{Types: splitList(*types), Actions: splitList(*actions)}
+	if filter.Repos, err = parseRepoGlobs("--repo", *repos); err != nil {
+		return err
+	}
+	if filter.ExcludeRepos, err = parseRepoGlobs("--exclude-repo", *excludeRepos); err != nil {
+		return err
+	}
+	if *since != "" {
+		if filter.Since, err = parseTimeBound("--since", *since, now, location, false); err != nil {
+			return err
+		}
+	}
+	if *until != "" {
+		if filter.Until, err = parseTimeBound("--until", *until, now, location, true); err != nil {
+			return err
+		}
+	}
+	var where *whereExpr
+	if *whereSrc != "" {
+		if where, err = compileWhere(*whereSrc); err != nil {
+			return &UsageError{Message: "invalid --where expression at " + err.Error()}
+		}
 	}
 
-	for _, event := range cresp {
+	opts := outputOptions{
+		Format:   *output,
+		Select:   *selectPath,
+		Time:     *timeFormat,
+		Location: location,
+		Headers:  isTerminal(stdout),
+		Commits:  *commits,
+		Locale:   loc,
+		Meta:     outputMeta{User: username, GeneratedAt: now.UTC()},
+	}
+	if *columns != "" {
+		opts.Columns = strings.Split(*columns, ",")
+	}
+	if *templateFile != "" || *format != "" {
+		if *templateFile != "" && *format != "" {
+			return &UsageError{Message: "--template and --format can't be used together"}
+		}
+		if *output != "text" {
+			return &UsageError{Message: "--template and --format can't be used with --output"}
+		}
+		if *selectPath != "" {
+			return &UsageError{Message: "--template and --format can't be used with --select"}
+		}
+		opts.Format, opts.Template, opts.TemplateName = "template", *format, "format"
+		if *templateFile != "" {
+			b, err := os.ReadFile(*templateFile)
+			if err != nil {
+				return fmt.Errorf("unable to read template: %w", err)
+			}
+			opts.Template, opts.TemplateName = string(b), filepath.Base(*templateFile)
+		}
+	}
+	w, err := newOutputWriter(stdout, opts)
+	if err != nil {
+		return err
+	}
 
-		var s string
-		var err error
+	c, err := cf.newClient(stderr)
+	if err != nil {
+		return err
+	}
 
-		if event.Type == "CreateEvent" {
-			s, err = parseCreateEvent(event.Payload, event.Repo.Name)
-		} else if event.Type == "DeleteEvent" {
-			s, err = parseDeleteEvent(event.Payload, event.Repo.Name)
-		} else if event.Type == "IssuesEvent" {
-			s, err = parseIssuesEvent(event.Payload, event.Repo.Name)
-		} else if event.Type == "PullRequestEvent" {
-			s, err = parsePullRequestEvent(event.Payload, event.Repo.Name)
-		} else if event.Type == "PushEvent" {
-			s, err = parsePushEvent(event.Payload, event.Repo.Name)
-		} else if event.Type == "ReleaseEvent" {
-			s, err = parseReleaseEvent(event.Payload)
+	private := false
+	if c.token != "" {
+		// Tokens that can't read /user, such as the GITHUB_TOKEN of GitHub
+		// Actions, still fetch public events.
+		if login, err := c.authenticatedUser(); err != nil {
+			c.debugf("unable to look up the authenticated user, private events excluded: %s", err)
+		} else {
+			private = strings.EqualFold(login, username)
+			c.debugf("authenticated as %s, private events included: %t", login, private)
 		}
+	}
 
-		if err != nil {
-			panic(err)
+	printed := 0
+	var skipped []skippedEvent
+	err = c.fetchEvents(c.eventsURL(username, private), *maxPages, func(cresp Event) error {
+		for _, event := range cresp {
+			if filter.exhausted(event) {
+				return errStopPagination
+			}
+			if !filter.match(event) {
+				continue
+			}
+			if where != nil {
+				ok, err := where.match(event)
+				if err != nil {
+					return fmt.Errorf("unable to evaluate --where for %s in %s at %w", event.Type, event.Repo.Name, err)
+				}
+				if !ok {
+					continue
+				}
+			}
+
+			a := newActivity(event)
+			if a.Err != nil && *strict {
+				return fmt.Errorf("%s in %s: %w", a.Type, a.Repo, a.Err)
+			} else if a.Err != nil {
+				skipped = append(skipped, skippedEvent{Type: a.Type, Repo: a.Repo, Err: a.Err})
+			}
+			if err := w.WriteActivity(a); err != nil {
+				return err
+			}
+			printed++
+			if *limit > 0 && printed >= *limit {
+				return errStopPagination
+			}
 		}
-		fmt.Println(s)
+		return nil
+	})
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
 	}
+	printSkipped(stderr, skipped)
 
+	if err != nil && printed > 0 {
+		return &PartialError{Printed: printed, Err: err}
+	}
+	return err
+}
+
+// isTerminal reports whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintf(os.Stderr, "github-activity: %s\n", err)
+		os.Exit(exitCode(err))
+	}
 }
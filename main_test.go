@@ -1,47 +1,15 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 )
 
-func TestCheckStatusCode(t *testing.T) {
-	t.Run("Successfully validates success status code", func(t *testing.T) {
-		statusCode := 200
-		exp := "Success"
-		s, err := checkStatusCode(statusCode)
-		require.Nil(t, err)
-		require.Equal(t, exp, s)
-	})
-
-	t.Run("Successfully validates forbidden status code", func(t *testing.T) {
-		statusCode := 403
-		exp := "forbidden"
-		s, err := checkStatusCode(statusCode)
-		require.EqualError(t, err, exp)
-		require.Empty(t, s)
-	})
-
-	t.Run("Successfully validates not found status code", func(t *testing.T) {
-		statusCode := 404
-		exp := "not found"
-		s, err := checkStatusCode(statusCode)
-		require.EqualError(t, err, exp)
-		require.Empty(t, s)
-	})
-
-	t.Run("Successfully validates service unavailable status code", func(t *testing.T) {
-		statusCode := 503
-		exp := "service unavailable"
-		s, err := checkStatusCode(statusCode)
-		require.EqualError(t, err, exp)
-		require.Empty(t, s)
-	})
-}
-
 func TestParseCreateEvent(t *testing.T) {
 	t.Run("Successfully validates CreateEvent for repository", func(t *testing.T) {
 		payload := `{
@@ -52,7 +20,7 @@ func TestParseCreateEvent(t *testing.T) {
 						"pusher_type": "user"
 					}`
 		reponame := "devUser/my-repo"
-		exp := fmt.Sprintf("Created new repository %s", reponame)
+		exp := fmt.Sprintf("Created new repository %s with default branch main: A brand new repository", reponame)
 		s, err := parseCreateEvent(json.RawMessage(payload), reponame)
 		require.Nil(t, err)
 		require.Equal(t, exp, s)
@@ -67,7 +35,7 @@ func TestParseCreateEvent(t *testing.T) {
 						"pusher_type": "user"
 					}`
 		reponame := "devUser/my-repo"
-		exp := fmt.Sprintf("Created new branch %s", reponame)
+		exp := fmt.Sprintf("Created branch feature-branch in %s", reponame)
 		s, err := parseCreateEvent(json.RawMessage(payload), reponame)
 		require.Nil(t, err)
 		require.Equal(t, exp, s)
@@ -82,7 +50,36 @@ func TestParseCreateEvent(t *testing.T) {
 						"pusher_type": "user"
 					}`
 		reponame := "devUser/my-repo"
-		exp := fmt.Sprintf("Created new tag %s", reponame)
+		exp := fmt.Sprintf("Created tag v1.0.0 in %s", reponame)
+		s, err := parseCreateEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates CreateEvent for repository without description", func(t *testing.T) {
+		payload := `{
+						"ref": null,
+						"ref_type": "repository",
+						"master_branch": "main",
+						"description": null,
+						"pusher_type": "user"
+					}`
+		reponame := "devUser/my-repo"
+		exp := "Created new repository devUser/my-repo with default branch main"
+		s, err := parseCreateEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates CreateEvent for branch with null ref", func(t *testing.T) {
+		payload := `{
+						"ref": null,
+						"ref_type": "branch",
+						"master_branch": "main",
+						"pusher_type": "user"
+					}`
+		reponame := "devUser/my-repo"
+		exp := "Created a branch in devUser/my-repo"
 		s, err := parseCreateEvent(json.RawMessage(payload), reponame)
 		require.Nil(t, err)
 		require.Equal(t, exp, s)
@@ -111,7 +108,7 @@ func TestParseDeleteEvent(t *testing.T) {
 						"pusher_type": "user"
 					}`
 		reponame := "devUser/my-repo"
-		exp := fmt.Sprintf("Deleted branch %s\n", reponame)
+		exp := fmt.Sprintf("Deleted branch feature-branch in %s", reponame)
 		s, err := parseDeleteEvent(json.RawMessage(payload), reponame)
 		require.Nil(t, err)
 		require.Equal(t, exp, s)
@@ -123,7 +120,20 @@ func TestParseDeleteEvent(t *testing.T) {
 						"pusher_type": "user"
 					}`
 		reponame := "devUser/my-repo"
-		exp := fmt.Sprintf("Deleted tag %s\n", reponame)
+		exp := fmt.Sprintf("Deleted tag v1.0.0 in %s", reponame)
+		s, err := parseDeleteEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates DeleteEvent for tag with null ref", func(t *testing.T) {
+		payload := `{
+						"ref": null,
+						"ref_type": "tag",
+						"pusher_type": "user"
+					}`
+		reponame := "devUser/my-repo"
+		exp := "Deleted a tag in devUser/my-repo"
 		s, err := parseDeleteEvent(json.RawMessage(payload), reponame)
 		require.Nil(t, err)
 		require.Equal(t, exp, s)
@@ -339,6 +349,122 @@ func TestParseIssuesEvent(t *testing.T) {
 		require.Equal(t, exp, s)
 	})
 
+	t.Run("Successfully validates IssuesEvent for transferred issue", func(t *testing.T) {
+		payload := `{
+						"action": "transferred",
+						"issue": {
+							"number": 42,
+							"title": "Bug: Crash on startup (Updated)",
+							"state": "open"
+						},
+						"changes": {
+							"new_repository": {
+								"full_name": "devUser/awesome-tool"
+							},
+							"new_issue": {
+								"number": 7,
+								"html_url": "https://github.com/devUser/awesome-tool/issues/7"
+							}
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "Issue 42. Bug: Crash on startup (Updated) for devUser/awesome-project is transferred to devUser/awesome-tool at https://github.com/devUser/awesome-tool/issues/7"
+		s, err := parseIssuesEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates IssuesEvent for locked issue", func(t *testing.T) {
+		payload := `{
+						"action": "locked",
+						"issue": {
+							"number": 42,
+							"title": "Bug: Crash on startup (Updated)",
+							"locked": true,
+							"active_lock_reason": "too heated"
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "Issue 42. Bug: Crash on startup (Updated) for devUser/awesome-project is locked as too heated"
+		s, err := parseIssuesEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates IssuesEvent for milestoned issue", func(t *testing.T) {
+		payload := `{
+						"action": "milestoned",
+						"issue": {
+							"number": 42,
+							"title": "Bug: Crash on startup (Updated)"
+						},
+						"milestone": {
+							"number": 3,
+							"title": "v1.2.0"
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "Issue 42. Bug: Crash on startup (Updated) for devUser/awesome-project is added to milestone v1.2.0"
+		s, err := parseIssuesEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates IssuesEvent for demilestoned issue", func(t *testing.T) {
+		payload := `{
+						"action": "demilestoned",
+						"issue": {
+							"number": 42,
+							"title": "Bug: Crash on startup (Updated)"
+						},
+						"milestone": {
+							"number": 3,
+							"title": "v1.2.0"
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "Issue 42. Bug: Crash on startup (Updated) for devUser/awesome-project is removed from milestone v1.2.0"
+		s, err := parseIssuesEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates IssuesEvent for typed issue", func(t *testing.T) {
+		payload := `{
+						"action": "typed",
+						"issue": {
+							"number": 42,
+							"title": "Bug: Crash on startup (Updated)"
+						},
+						"type": {
+							"id": 12,
+							"name": "Bug"
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "Issue 42. Bug: Crash on startup (Updated) for devUser/awesome-project is typed as Bug"
+		s, err := parseIssuesEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates IssuesEvent for actions without extra fields", func(t *testing.T) {
+		reponame := "devUser/awesome-project"
+		for action, state := range map[string]string{
+			"deleted":  "deleted",
+			"pinned":   "pinned",
+			"unpinned": "unpinned",
+			"locked":   "locked",
+			"unlocked": "unlocked",
+		} {
+			payload := fmt.Sprintf(`{"action": %q, "issue": {"number": 42, "title": "Crash"}}`, action)
+			exp := fmt.Sprintf("Issue 42. Crash for %s is %s", reponame, state)
+			s, err := parseIssuesEvent(json.RawMessage(payload), reponame)
+			require.Nil(t, err)
+			require.Equal(t, exp, s)
+		}
+	})
+
 	t.Run("Successfully validates error for IssuesEvent", func(t *testing.T) {
 		payload := `{
 						"action": "",
@@ -398,7 +524,7 @@ func TestParsePullRequestEvent(t *testing.T) {
 		prnum := 43
 		prtitle := "Fix bug Y"
 		prurl := "https://api.github.com/repos/collabUser/project-repo/pulls/43"
-		exp := fmt.Sprintf("Pull request %d. %s for %s is closed at %s", prnum, prtitle, reponame, prurl)
+		exp := fmt.Sprintf("Pull request %d. %s for %s is merged at %s", prnum, prtitle, reponame, prurl)
 		s, err := parsePullRequestEvent(json.RawMessage(payload), reponame)
 		require.Nil(t, err)
 		require.Equal(t, exp, s)
@@ -501,6 +627,179 @@ func TestParsePullRequestEvent(t *testing.T) {
 		require.Equal(t, exp, s)
 	})
 
+	t.Run("Successfully validates PullRequestEvent for pull request merged into base branch", func(t *testing.T) {
+		payload := `{
+						"action": "closed",
+						"number": 43,
+						"pull_request": {
+							"url": "https://api.github.com/repos/devUser/awesome-project/pulls/43",
+							"title": "Fix bug Y",
+							"state": "closed",
+							"merged": true,
+							"merged_at": "2024-10-12T08:30:00Z",
+							"base": {"ref": "main"},
+							"head": {"ref": "fix-bug-y"}
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "Pull request 43. Fix bug Y for devUser/awesome-project is merged into main at https://api.github.com/repos/devUser/awesome-project/pulls/43"
+		s, err := parsePullRequestEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates PullRequestEvent for pull request closed without merging", func(t *testing.T) {
+		payload := `{
+						"action": "closed",
+						"number": 43,
+						"pull_request": {
+							"url": "https://api.github.com/repos/devUser/awesome-project/pulls/43",
+							"title": "Fix bug Y",
+							"state": "closed",
+							"merged": false,
+							"merged_at": null,
+							"base": {"ref": "main"}
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "Pull request 43. Fix bug Y for devUser/awesome-project is closed without merging at https://api.github.com/repos/devUser/awesome-project/pulls/43"
+		s, err := parsePullRequestEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates PullRequestEvent for pull request merged with only merged_at", func(t *testing.T) {
+		payload := `{
+						"action": "closed",
+						"number": 43,
+						"pull_request": {
+							"url": "https://api.github.com/repos/devUser/awesome-project/pulls/43",
+							"title": "Fix bug Y",
+							"merged_at": "2024-10-12T08:30:00Z",
+							"base": {"ref": "main"}
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "Pull request 43. Fix bug Y for devUser/awesome-project is merged into main at https://api.github.com/repos/devUser/awesome-project/pulls/43"
+		s, err := parsePullRequestEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates PullRequestEvent for draft pull request opened", func(t *testing.T) {
+		payload := `{
+						"action": "opened",
+						"number": 47,
+						"pull_request": {
+							"url": "https://api.github.com/repos/devUser/awesome-project/pulls/47",
+							"title": "Add feature Z",
+							"state": "open",
+							"draft": true,
+							"base": {"ref": "main"},
+							"head": {"ref": "feature-z"}
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "Pull request 47. Add feature Z for devUser/awesome-project is opened as a draft from feature-z into main at https://api.github.com/repos/devUser/awesome-project/pulls/47"
+		s, err := parsePullRequestEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates PullRequestEvent for labeled pull request", func(t *testing.T) {
+		payload := `{
+						"action": "labeled",
+						"number": 47,
+						"pull_request": {
+							"url": "https://api.github.com/repos/devUser/awesome-project/pulls/47",
+							"title": "Add feature Z",
+							"labels": [{"name": "enhancement"}]
+						},
+						"label": {"name": "enhancement"}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "Pull request 47. Add feature Z for devUser/awesome-project is labeled as enhancement, https://api.github.com/repos/devUser/awesome-project/pulls/47"
+		s, err := parsePullRequestEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates PullRequestEvent for review requested from a user or team", func(t *testing.T) {
+		reponame := "devUser/awesome-project"
+		for reviewer, exp := range map[string]string{
+			`"requested_reviewer": {"login": "reviewerUser"}`: "Pull request 47. Add feature Z for devUser/awesome-project has a review requested from reviewerUser, https://api.github.com/repos/devUser/awesome-project/pulls/47",
+			`"requested_team": {"name": "core"}`:              "Pull request 47. Add feature Z for devUser/awesome-project has a review requested from core, https://api.github.com/repos/devUser/awesome-project/pulls/47",
+		} {
+			payload := `{
+						"action": "review_requested",
+						"number": 47,
+						"pull_request": {
+							"url": "https://api.github.com/repos/devUser/awesome-project/pulls/47",
+							"title": "Add feature Z",
+							"requested_reviewers": [{"login": "reviewerUser"}]
+						},
+						` + reviewer + `
+					}`
+			s, err := parsePullRequestEvent(json.RawMessage(payload), reponame)
+			require.Nil(t, err)
+			require.Equal(t, exp, s)
+		}
+	})
+
+	t.Run("Successfully validates PullRequestEvent for unassigned pull request", func(t *testing.T) {
+		payload := `{
+						"action": "unassigned",
+						"number": 45,
+						"pull_request": {
+							"url": "https://api.github.com/repos/devUser/awesome-project/pulls/45",
+							"title": "Add CI/CD pipeline"
+						},
+						"assignee": {"login": "devUser"}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "Pull request 45. Add CI/CD pipeline for devUser/awesome-project is unassigned from devUser, https://api.github.com/repos/devUser/awesome-project/pulls/45"
+		s, err := parsePullRequestEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates PullRequestEvent for dequeued pull request", func(t *testing.T) {
+		payload := `{
+						"action": "dequeued",
+						"number": 45,
+						"pull_request": {
+							"url": "https://api.github.com/repos/devUser/awesome-project/pulls/45",
+							"title": "Add CI/CD pipeline"
+						},
+						"reason": "CI failed"
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "Pull request 45. Add CI/CD pipeline for devUser/awesome-project is removed from the merge queue (CI failed) at https://api.github.com/repos/devUser/awesome-project/pulls/45"
+		s, err := parsePullRequestEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates PullRequestEvent for actions without extra fields", func(t *testing.T) {
+		reponame := "devUser/awesome-project"
+		for action, state := range map[string]string{
+			"edited":              "is edited",
+			"ready_for_review":    "is ready for review",
+			"converted_to_draft":  "is converted to a draft",
+			"locked":              "is locked",
+			"unlocked":            "is unlocked",
+			"auto_merge_enabled":  "has auto-merge enabled",
+			"auto_merge_disabled": "has auto-merge disabled",
+			"enqueued":            "is added to the merge queue",
+		} {
+			payload := fmt.Sprintf(`{"action": %q, "number": 42, "pull_request": {"title": "Crash", "url": "https://api.github.com/repos/devUser/awesome-project/pulls/42"}}`, action)
+			exp := fmt.Sprintf("Pull request 42. Crash for %s %s at https://api.github.com/repos/devUser/awesome-project/pulls/42", reponame, state)
+			s, err := parsePullRequestEvent(json.RawMessage(payload), reponame)
+			require.Nil(t, err)
+			require.Equal(t, exp, s)
+		}
+	})
+
 	t.Run("Successfully validates error for PullRequestEvent", func(t *testing.T) {
 		payload := `{
 						"action": "",
@@ -546,7 +845,7 @@ func TestParsePushEvent(t *testing.T) {
 					}`
 		reponame := "devUser/awesome-project"
 		size := 1
-		exp := fmt.Sprintf("Pushed %d commit to %s", size, reponame)
+		exp := fmt.Sprintf("Pushed %d commit to feature-branch in %s", size, reponame)
 		s, err := parsePushEvent(json.RawMessage(payload), reponame)
 		require.Nil(t, err)
 		require.Equal(t, exp, s)
@@ -584,47 +883,125 @@ func TestParsePushEvent(t *testing.T) {
 				}`
 		reponame := "devUser/awesome-project"
 		size := 2
-		exp := fmt.Sprintf("Pushed %d commits to %s", size, reponame)
+		exp := fmt.Sprintf("Pushed %d commits to main in %s", size, reponame)
 		s, err := parsePushEvent(json.RawMessage(payload), reponame)
 		require.Nil(t, err)
 		require.Equal(t, exp, s)
 	})
-	t.Run("Successfully validates error for PushEvent", func(t *testing.T) {
+	t.Run("Successfully validates PushEvent with commits already in the repository", func(t *testing.T) {
+		payload := `{
+					"size": 3,
+					"distinct_size": 1,
+					"ref": "refs/heads/main",
+					"head": "a1b2c3d4e5f67890abcdef1234567890abcdef12",
+					"before": "1234567890abcdef1234567890abcdef12345678"
+				}`
+		reponame := "devUser/awesome-project"
+		exp := "Pushed 3 commits (1 new) to main in devUser/awesome-project"
+		s, err := parsePushEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates PushEvent for force-push", func(t *testing.T) {
 		payload := `{
 					"push_id": 1010101010,
 					"size": 0,
-					"distinct_size": 2,
+					"distinct_size": 0,
 					"ref": "refs/heads/main",
 					"head": "a1b2c3d4e5f67890abcdef1234567890abcdef12",
 					"before": "1234567890abcdef1234567890abcdef12345678",
-					"commits": [
-						{
-						"sha": "a1b2c3d4e5f67890abcdef1234567890abcdef12",
-						"author": {
-							"email": "devUser@example.com",
-							"name": "devUser"
-						},
-						"message": "Add feature X implementation",
-						"distinct": true,
-						"url": "https://api.github.com/repos/devUser/awesome-project/commits/a1b2c3d4e5f67890abcdef1234567890abcdef12"
-						},
-						{
-						"sha": "1234abcd5678ef90abcdef1234567890abcdef13",
-						"author": {
-							"email": "collaborator@example.com",
-							"name": "collaboratorUser"
-						},
-						"message": "Fix bug Y in feature X",
-						"distinct": true,
-						"url": "https://api.github.com/repos/devUser/awesome-project/commits/1234abcd5678ef90abcdef1234567890abcdef13"
-						}
-					]
+					"commits": []
 				}`
 		reponame := "devUser/awesome-project"
-		exp := "unable to parse"
+		exp := "Force-pushed main in devUser/awesome-project from 1234567 to a1b2c3d"
 		s, err := parsePushEvent(json.RawMessage(payload), reponame)
-		require.EqualError(t, err, exp)
-		require.Empty(t, s)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates PushEvent creating a branch without commits", func(t *testing.T) {
+		payload := `{
+					"size": 0,
+					"distinct_size": 0,
+					"ref": "refs/heads/feature-x",
+					"head": "a1b2c3d4e5f67890abcdef1234567890abcdef12",
+					"before": "0000000000000000000000000000000000000000",
+					"commits": []
+				}`
+		reponame := "devUser/awesome-project"
+		exp := "Pushed new branch feature-x to devUser/awesome-project"
+		s, err := parsePushEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates PushEvent without size and commits is not a force-push", func(t *testing.T) {
+		payload := `{
+					"ref": "refs/heads/main",
+					"head": "a1b2c3d4e5f67890abcdef1234567890abcdef12",
+					"before": "1234567890abcdef1234567890abcdef12345678"
+				}`
+		reponame := "devUser/awesome-project"
+		exp := "Pushed to main in devUser/awesome-project"
+		s, err := parsePushEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates PushEvent deleting a branch", func(t *testing.T) {
+		payload := `{
+					"size": 0,
+					"ref": "refs/heads/feature-x",
+					"head": "0000000000000000000000000000000000000000",
+					"before": "1234567890abcdef1234567890abcdef12345678",
+					"commits": []
+				}`
+		reponame := "devUser/awesome-project"
+		exp := "Deleted branch feature-x in devUser/awesome-project with a push"
+		s, err := parsePushEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates PushEvent creating a tag", func(t *testing.T) {
+		payload := `{
+					"ref": "refs/tags/v1.0.0",
+					"head": "a1b2c3d4e5f67890abcdef1234567890abcdef12",
+					"before": "0000000000000000000000000000000000000000"
+				}`
+		reponame := "devUser/awesome-project"
+		exp := "Pushed new tag v1.0.0 to devUser/awesome-project"
+		s, err := parsePushEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates PushEvent to a tag", func(t *testing.T) {
+		payload := `{
+					"size": 1,
+					"ref": "refs/tags/v1.0.0",
+					"head": "a1b2c3d4e5f67890abcdef1234567890abcdef12",
+					"before": "1234567890abcdef1234567890abcdef12345678"
+				}`
+		reponame := "devUser/awesome-project"
+		exp := "Pushed 1 commit to tag v1.0.0 in devUser/awesome-project"
+		s, err := parsePushEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates error for PushEvent", func(t *testing.T) {
+		payload := `{
+					"push_id": 1010101010,
+					"size": 0,
+					"ref": "refs/heads/main"
+				}`
+		reponame := "devUser/awesome-project"
+		exp := "unable to parse, push is empty"
+		s, err := parsePushEvent(json.RawMessage(payload), reponame)
+		require.EqualError(t, err, exp)
+		require.Empty(t, s)
 	})
 }
 
@@ -650,7 +1027,7 @@ func TestParseReleaseEvent(t *testing.T) {
 		name := "Version 1.0.0"
 		url := "https://github.com/devUser/awesome-project/releases/tag/v1.0.0"
 		exp := fmt.Sprintf("%s published at %s", name, url)
-		s, err := parseReleaseEvent(json.RawMessage(payload))
+		s, err := parseReleaseEvent(json.RawMessage(payload), "devUser/awesome-project")
 		require.Nil(t, err)
 		require.Equal(t, exp, s)
 	})
@@ -675,7 +1052,7 @@ func TestParseReleaseEvent(t *testing.T) {
 		name := "Version 1.1.0 Beta"
 		url := "https://github.com/collabUser/cool-tool/releases/tag/v1.1.0-beta"
 		exp := fmt.Sprintf("%s prereleased at %s", name, url)
-		s, err := parseReleaseEvent(json.RawMessage(payload))
+		s, err := parseReleaseEvent(json.RawMessage(payload), "devUser/awesome-project")
 		require.Nil(t, err)
 		require.Equal(t, exp, s)
 	})
@@ -701,7 +1078,7 @@ func TestParseReleaseEvent(t *testing.T) {
 		name := "Draft Release"
 		url := "https://github.com/newUser/new-project/releases/tag/v0.1.0"
 		exp := fmt.Sprintf("%s created at %s", name, url)
-		s, err := parseReleaseEvent(json.RawMessage(payload))
+		s, err := parseReleaseEvent(json.RawMessage(payload), "devUser/awesome-project")
 		require.Nil(t, err)
 		require.Equal(t, exp, s)
 	})
@@ -725,8 +1102,447 @@ func TestParseReleaseEvent(t *testing.T) {
 						}
 					}`
 		exp := "unable to parse"
-		s, err := parseReleaseEvent(json.RawMessage(payload))
+		s, err := parseReleaseEvent(json.RawMessage(payload), "devUser/awesome-project")
+		require.EqualError(t, err, exp)
+		require.Empty(t, s)
+	})
+}
+
+func TestParseForkEvent(t *testing.T) {
+	t.Run("Successfully validates ForkEvent", func(t *testing.T) {
+		payload := `{
+						"forkee": {
+							"id": 123456789,
+							"name": "awesome-project",
+							"full_name": "collabUser/awesome-project",
+							"private": false,
+							"html_url": "https://github.com/collabUser/awesome-project",
+							"fork": true
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		fork := "collabUser/awesome-project"
+		url := "https://github.com/collabUser/awesome-project"
+		exp := fmt.Sprintf("Forked %s to %s at %s", reponame, fork, url)
+		s, err := parseForkEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates error for ForkEvent", func(t *testing.T) {
+		payload := `{
+						"forkee": {}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "unable to parse, fork repository is empty"
+		s, err := parseForkEvent(json.RawMessage(payload), reponame)
+		require.EqualError(t, err, exp)
+		require.Empty(t, s)
+	})
+}
+
+func TestParseWatchEvent(t *testing.T) {
+	t.Run("Successfully validates WatchEvent with action started", func(t *testing.T) {
+		payload := `{
+						"action": "started"
+					}`
+		reponame := "devUser/awesome-project"
+		exp := fmt.Sprintf("Starred %s", reponame)
+		s, err := parseWatchEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates error for WatchEvent", func(t *testing.T) {
+		payload := `{
+						"action": ""
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "unable to parse"
+		s, err := parseWatchEvent(json.RawMessage(payload), reponame)
+		require.EqualError(t, err, exp)
+		require.Empty(t, s)
+	})
+}
+
+func TestParsePublicEvent(t *testing.T) {
+	t.Run("Successfully validates PublicEvent", func(t *testing.T) {
+		reponame := "devUser/awesome-project"
+		exp := fmt.Sprintf("Made %s public", reponame)
+		s, err := parseEvent("PublicEvent", json.RawMessage(`{}`), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+}
+
+func TestParseIssueCommentEvent(t *testing.T) {
+	t.Run("Successfully validates IssueCommentEvent with action created", func(t *testing.T) {
+		payload := `{
+						"action": "created",
+						"issue": {
+							"number": 42,
+							"title": "Crash on start",
+							"state": "open"
+						},
+						"comment": {
+							"id": 2468,
+							"body": "I can reproduce this on macOS.\n\nStack trace attached below, it fails while loading the config file at startup.",
+							"html_url": "https://github.com/devUser/awesome-project/issues/42#issuecomment-2468"
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := `Commented on issue 42. Crash on start for devUser/awesome-project: "I can reproduce this on macOS. Stack trace attached below, …" ` +
+			"at https://github.com/devUser/awesome-project/issues/42#issuecomment-2468"
+		s, err := parseIssueCommentEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates IssueCommentEvent with action deleted", func(t *testing.T) {
+		payload := `{
+						"action": "deleted",
+						"issue": {
+							"number": 42,
+							"title": "Crash on start"
+						},
+						"comment": {
+							"body": "Duplicate of #41",
+							"html_url": "https://github.com/devUser/awesome-project/issues/42#issuecomment-2469"
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := `Deleted a comment on issue 42. Crash on start for devUser/awesome-project: "Duplicate of #41"`
+		s, err := parseIssueCommentEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates error for IssueCommentEvent", func(t *testing.T) {
+		payload := `{
+						"action": "",
+						"issue": {
+							"number": 42
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "unable to parse"
+		s, err := parseIssueCommentEvent(json.RawMessage(payload), reponame)
+		require.EqualError(t, err, exp)
+		require.Empty(t, s)
+	})
+}
+
+func TestParseCommitCommentEvent(t *testing.T) {
+	t.Run("Successfully validates CommitCommentEvent without action", func(t *testing.T) {
+		payload := `{
+						"comment": {
+							"commit_id": "a1b2c3d4e5f67890abcdef1234567890abcdef12",
+							"body": "Nice catch!",
+							"html_url": "https://github.com/devUser/awesome-project/commit/a1b2c3d4e5f67890abcdef1234567890abcdef12#commitcomment-1357"
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := `Commented on commit a1b2c3d for devUser/awesome-project: "Nice catch!" ` +
+			"at https://github.com/devUser/awesome-project/commit/a1b2c3d4e5f67890abcdef1234567890abcdef12#commitcomment-1357"
+		s, err := parseCommitCommentEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates error for CommitCommentEvent", func(t *testing.T) {
+		payload := `{
+						"action": "created",
+						"comment": {
+							"body": "Nice catch!"
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "unable to parse, commit is empty"
+		s, err := parseCommitCommentEvent(json.RawMessage(payload), reponame)
+		require.EqualError(t, err, exp)
+		require.Empty(t, s)
+	})
+}
+
+func TestParsePullRequestReviewCommentEvent(t *testing.T) {
+	t.Run("Successfully validates PullRequestReviewCommentEvent with action edited", func(t *testing.T) {
+		payload := `{
+						"action": "edited",
+						"pull_request": {
+							"number": 7,
+							"title": "Add feature X",
+							"html_url": "https://github.com/devUser/awesome-project/pull/7"
+						},
+						"comment": {
+							"body": "Could this use   the existing helper?",
+							"html_url": "https://github.com/devUser/awesome-project/pull/7#discussion_r1122"
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := `Edited a comment on pull request 7. Add feature X for devUser/awesome-project: "Could this use the existing helper?" ` +
+			"at https://github.com/devUser/awesome-project/pull/7#discussion_r1122"
+		s, err := parsePullRequestReviewCommentEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates error for PullRequestReviewCommentEvent", func(t *testing.T) {
+		payload := `{
+						"action": "resolved",
+						"pull_request": {
+							"number": 7
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "unable to parse"
+		s, err := parsePullRequestReviewCommentEvent(json.RawMessage(payload), reponame)
+		require.EqualError(t, err, exp)
+		require.Empty(t, s)
+	})
+}
+
+func TestParsePullRequestReviewEvent(t *testing.T) {
+	t.Run("Successfully validates PullRequestReviewEvent with state approved", func(t *testing.T) {
+		payload := `{
+						"action": "created",
+						"review": {
+							"id": 80,
+							"body": "Looks good!",
+							"state": "approved",
+							"html_url": "https://github.com/devUser/awesome-project/pull/7#pullrequestreview-80"
+						},
+						"pull_request": {
+							"number": 7,
+							"title": "Add feature X",
+							"html_url": "https://github.com/devUser/awesome-project/pull/7"
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		url := "https://github.com/devUser/awesome-project/pull/7#pullrequestreview-80"
+		exp := fmt.Sprintf("Approved pull request 7. Add feature X for %s at %s", reponame, url)
+		s, err := parsePullRequestReviewEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates PullRequestReviewEvent with state changes requested", func(t *testing.T) {
+		payload := `{
+						"action": "created",
+						"review": {
+							"state": "CHANGES_REQUESTED",
+							"html_url": "https://github.com/devUser/awesome-project/pull/7#pullrequestreview-81"
+						},
+						"pull_request": {
+							"number": 7,
+							"title": "Add feature X"
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		url := "https://github.com/devUser/awesome-project/pull/7#pullrequestreview-81"
+		exp := fmt.Sprintf("Requested changes on pull request 7. Add feature X for %s at %s", reponame, url)
+		s, err := parsePullRequestReviewEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates PullRequestReviewEvent with state commented", func(t *testing.T) {
+		payload := `{
+						"action": "created",
+						"review": {
+							"state": "commented",
+							"html_url": "https://github.com/devUser/awesome-project/pull/7#pullrequestreview-82"
+						},
+						"pull_request": {
+							"number": 7,
+							"title": "Add feature X"
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		url := "https://github.com/devUser/awesome-project/pull/7#pullrequestreview-82"
+		exp := fmt.Sprintf("Reviewed pull request 7. Add feature X for %s with comments at %s", reponame, url)
+		s, err := parsePullRequestReviewEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates error for PullRequestReviewEvent", func(t *testing.T) {
+		payload := `{
+						"action": "created",
+						"review": {
+							"state": ""
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "unable to parse, review state is empty"
+		s, err := parsePullRequestReviewEvent(json.RawMessage(payload), reponame)
 		require.EqualError(t, err, exp)
 		require.Empty(t, s)
 	})
 }
+
+func TestParseGollumEvent(t *testing.T) {
+	t.Run("Successfully validates GollumEvent with several pages", func(t *testing.T) {
+		payload := `{
+						"pages": [
+							{
+								"page_name": "Home",
+								"title": "Home",
+								"summary": null,
+								"action": "edited",
+								"sha": "91ea1bd42aa2ba166b86e8aefe049e9837214e67",
+								"html_url": "https://github.com/devUser/awesome-project/wiki/Home"
+							},
+							{
+								"page_name": "Getting-Started",
+								"title": "Getting Started",
+								"summary": null,
+								"action": "created",
+								"sha": "0f3c9e8a2b4d6f8e1a3c5e7b9d1f3a5c7e9b1d3f",
+								"html_url": "https://github.com/devUser/awesome-project/wiki/Getting-Started"
+							}
+						]
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "Edited wiki page Home for devUser/awesome-project at https://github.com/devUser/awesome-project/wiki/Home\n" +
+			"Created wiki page Getting Started for devUser/awesome-project at https://github.com/devUser/awesome-project/wiki/Getting-Started"
+		s, err := parseGollumEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates error for GollumEvent", func(t *testing.T) {
+		payload := `{
+						"pages": []
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "unable to parse, no pages"
+		s, err := parseGollumEvent(json.RawMessage(payload), reponame)
+		require.EqualError(t, err, exp)
+		require.Empty(t, s)
+	})
+}
+
+func TestParseMemberEvent(t *testing.T) {
+	t.Run("Successfully validates MemberEvent with action added", func(t *testing.T) {
+		payload := `{
+						"action": "added",
+						"member": {
+							"login": "collabUser",
+							"html_url": "https://github.com/collabUser"
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := fmt.Sprintf("Added collabUser as a collaborator to %s", reponame)
+		s, err := parseMemberEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates MemberEvent with action removed", func(t *testing.T) {
+		payload := `{
+						"action": "removed",
+						"member": {
+							"login": "collabUser"
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := fmt.Sprintf("Removed collabUser as a collaborator from %s", reponame)
+		s, err := parseMemberEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates MemberEvent with permission changed", func(t *testing.T) {
+		payload := `{
+						"action": "edited",
+						"member": {
+							"login": "collabUser"
+						},
+						"changes": {
+							"old_permission": {
+								"from": "write"
+							},
+							"permission": {
+								"to": "admin"
+							}
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := fmt.Sprintf("Changed the permission of collabUser on %s from write to admin", reponame)
+		s, err := parseMemberEvent(json.RawMessage(payload), reponame)
+		require.Nil(t, err)
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates error for MemberEvent", func(t *testing.T) {
+		payload := `{
+						"action": "",
+						"member": {
+							"login": "collabUser"
+						}
+					}`
+		reponame := "devUser/awesome-project"
+		exp := "unable to parse"
+		s, err := parseMemberEvent(json.RawMessage(payload), reponame)
+		require.EqualError(t, err, exp)
+		require.Empty(t, s)
+	})
+}
+
+// setupRun points run at srv and isolates it from any token in the environment.
+func setupRun(t *testing.T, srv *httptest.Server) {
+	t.Helper()
+	t.Setenv("GITHUB_API_URL", srv.URL)
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GH_CONFIG_DIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_CACHE_HOME", "")
+	t.Setenv("NETRC", "")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "")
+	t.Setenv("HOME", t.TempDir())
+}
+
+func TestRun(t *testing.T) {
+	pages := []string{`[
+		{"type": "PushEvent", "repo": {"name": "devUser/awesome-project"}, "payload": {"size": 2}},
+		{"type": "PullRequestEvent", "repo": {"name": "devUser/awesome-project"}, "payload": {"number": 42}},
+		{"type": "ReleaseEvent", "repo": {"name": "devUser/awesome-project"}, "payload": {"action": "published", "release": {"name": "Version 1.0.0", "html_url": "https://github.com/devUser/awesome-project/releases/tag/v1.0.0"}}}
+	]`}
+
+	t.Run("Successfully validates fallback for events that can't be parsed", func(t *testing.T) {
+		setupRun(t, newTestServer(t, pages))
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"devUser"}, &stdout, &stderr)
+		require.Nil(t, err)
+		require.Equal(t, "Pushed 2 commits to devUser/awesome-project\n"+
+			"PullRequestEvent in devUser/awesome-project\n"+
+			"Version 1.0.0 published at https://github.com/devUser/awesome-project/releases/tag/v1.0.0\n", stdout.String())
+		require.Equal(t, "skipped 1 events that could not be parsed:\n"+
+			"  PullRequestEvent in devUser/awesome-project: unable to parse\n", stderr.String())
+	})
+
+	t.Run("Successfully validates strict mode stops at the first error", func(t *testing.T) {
+		setupRun(t, newTestServer(t, pages))
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--strict", "devUser"}, &stdout, &stderr)
+		require.EqualError(t, err, "PullRequestEvent in devUser/awesome-project: unable to parse (after printing 1 events)")
+		require.Equal(t, exitPartial, exitCode(err))
+		require.Equal(t, "Pushed 2 commits to devUser/awesome-project\n", stdout.String())
+	})
+
+	t.Run("Successfully validates unsupported event types are neither reported nor strict errors", func(t *testing.T) {
+		setupRun(t, newTestServer(t, []string{`[
+			{"type": "SponsorshipEvent", "repo": {"name": "devUser/awesome-project"}, "payload": {"action": "created"}},
+			{"type": "PushEvent", "repo": {"name": "devUser/awesome-project"}, "payload": {"size": 2}}
+		]`}))
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--strict", "devUser"}, &stdout, &stderr)
+		require.Nil(t, err)
+		require.Equal(t, "SponsorshipEvent in devUser/awesome-project\n"+
+			"Pushed 2 commits to devUser/awesome-project\n", stdout.String())
+		require.Empty(t, stderr.String())
+	})
+}
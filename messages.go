@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// defaultMessages are the built-in sentences of the parse*Event functions,
+// keyed by event type and action. The templates are executed with the
+// decoded payload and the repository name as .Repo.
+var defaultMessages = map[string]string{
+	"CreateEvent.repository": "Created new repository {{.Repo}}{{with .MasterBranch}} with default branch {{.}}{{end}}{{with .Description}}: {{.}}{{end}}",
+	"CreateEvent.branch":     "Created {{with .Ref}}branch {{.}}{{else}}a branch{{end}} in {{.Repo}}",
+	"CreateEvent.tag":        "Created {{with .Ref}}tag {{.}}{{else}}a tag{{end}} in {{.Repo}}",
+
+	"DeleteEvent.branch": "Deleted {{with .Ref}}branch {{.}}{{else}}a branch{{end}} in {{.Repo}}",
+	"DeleteEvent.tag":    "Deleted {{with .Ref}}tag {{.}}{{else}}a tag{{end}} in {{.Repo}}",
+
+	"IssuesEvent.opened":     "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is opened",
+	"IssuesEvent.edited":     "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is edited",
+	"IssuesEvent.closed":     "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is closed",
+	"IssuesEvent.reopened":   "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is reopened",
+	"IssuesEvent.assigned":   "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is assigned to {{.Assignee.Login}}",
+	"IssuesEvent.unassigned": "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is unassigned from {{.Assignee.Login}}",
+	"IssuesEvent.labeled":    "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is labeled as {{.Label.Name}}",
+	"IssuesEvent.unlabeled":  "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is unlabeled from {{.Label.Name}}",
+
+	"IssuesEvent.deleted":      "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is deleted",
+	"IssuesEvent.transferred":  "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is transferred to {{.Changes.NewRepository.FullName}}{{with .Changes.NewIssue.Url}} at {{.}}{{end}}",
+	"IssuesEvent.pinned":       "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is pinned",
+	"IssuesEvent.unpinned":     "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is unpinned",
+	"IssuesEvent.locked":       "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is locked{{with .Issue.ActiveLockReason}} as {{.}}{{end}}",
+	"IssuesEvent.unlocked":     "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is unlocked",
+	"IssuesEvent.milestoned":   "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is added to milestone {{.Milestone.Title}}",
+	"IssuesEvent.demilestoned": "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is removed from milestone {{.Milestone.Title}}",
+	"IssuesEvent.typed":        "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is typed as {{.IssueType.Name}}",
+	"IssuesEvent.untyped":      "Issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}} is no longer typed as {{.IssueType.Name}}",
+
+	"PullRequestEvent.opened":                 "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is opened{{if .PullRequest.Draft}} as a draft{{end}}{{with .PullRequest.Head.Ref}} from {{.}}{{end}}{{with .PullRequest.Base.Ref}} into {{.}}{{end}} at {{.PullRequest.Url}}",
+	"PullRequestEvent.closed":                 "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is {{if .IsMerged}}merged{{with .PullRequest.Base.Ref}} into {{.}}{{end}}{{else}}closed without merging{{end}} at {{.PullRequest.Url}}",
+	"PullRequestEvent.reopened":               "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is reopened at {{.PullRequest.Url}}",
+	"PullRequestEvent.edited":                 "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is edited at {{.PullRequest.Url}}",
+	"PullRequestEvent.assigned":               "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is assigned to {{.Assignee.Login}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.unassigned":             "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is unassigned from {{.Assignee.Login}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.labeled":                "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is labeled as {{.Label.Name}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.unlabeled":              "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is unlabeled from {{.Label.Name}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.review_requested":       "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} has a review requested from {{or .RequestedReviewer.Login .RequestedTeam.Name}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.review_request_removed": "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} no longer has a review requested from {{or .RequestedReviewer.Login .RequestedTeam.Name}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.ready_for_review":       "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is ready for review at {{.PullRequest.Url}}",
+	"PullRequestEvent.converted_to_draft":     "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is converted to a draft at {{.PullRequest.Url}}",
+	"PullRequestEvent.synchronize":            "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is synchronized, {{.PullRequest.Url}}",
+	"PullRequestEvent.locked":                 "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is locked at {{.PullRequest.Url}}",
+	"PullRequestEvent.unlocked":               "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is unlocked at {{.PullRequest.Url}}",
+	"PullRequestEvent.milestoned":             "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is added to milestone {{.PullRequest.Milestone.Title}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.demilestoned":           "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is removed from milestone {{.PullRequest.Milestone.Title}}, {{.PullRequest.Url}}",
+	"PullRequestEvent.auto_merge_enabled":     "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} has auto-merge enabled at {{.PullRequest.Url}}",
+	"PullRequestEvent.auto_merge_disabled":    "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} has auto-merge disabled{{with .Reason}} ({{.}}){{end}} at {{.PullRequest.Url}}",
+	"PullRequestEvent.enqueued":               "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is added to the merge queue at {{.PullRequest.Url}}",
+	"PullRequestEvent.dequeued":               "Pull request {{.Number}}. {{.PullRequest.Title}} for {{.Repo}} is removed from the merge queue{{with .Reason}} ({{.}}){{end}} at {{.PullRequest.Url}}",
+
+	"PushEvent":         `Pushed{{with .Size}} {{.}} {{plural . "commit" "commits"}}{{end}}{{if .Existing}} ({{.DistinctSize}} new){{end}} to {{with .Tag}}tag {{.}} in {{else}}{{with .Branch}}{{.}} in {{end}}{{end}}{{.Repo}}`,
+	"PushEvent.created": `Pushed new {{with .Tag}}tag {{.}}{{else}}branch {{.Branch}}{{end}} to {{.Repo}}`,
+	"PushEvent.deleted": `Deleted {{with .Tag}}tag {{.}}{{else}}branch {{.Branch}}{{end}} in {{.Repo}} with a push`,
+	"PushEvent.forced":  `Force-pushed {{with .Tag}}tag {{.}} in {{else}}{{with .Branch}}{{.}} in {{end}}{{end}}{{.Repo}} from {{shortSHA .Before}} to {{shortSHA .Head}}`,
+
+	"ReleaseEvent.published":   "{{.Release.Name}} published at {{.Release.Url}}",
+	"ReleaseEvent.prereleased": "{{.Release.Name}} prereleased at {{.Release.Url}}",
+	"ReleaseEvent.created":     "{{.Release.Name}} created at {{.Release.Url}}",
+
+	"ForkEvent":          "Forked {{.Repo}} to {{.Forkee.FullName}} at {{.Forkee.Url}}",
+	"WatchEvent.started": "Starred {{.Repo}}",
+	"PublicEvent":        "Made {{.Repo}} public",
+
+	"IssueCommentEvent.created": `Commented on issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}}: "{{excerpt .Comment.Body}}" at {{.Comment.Url}}`,
+	"IssueCommentEvent.edited":  `Edited a comment on issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}}: "{{excerpt .Comment.Body}}" at {{.Comment.Url}}`,
+	"IssueCommentEvent.deleted": `Deleted a comment on issue {{.Issue.Number}}. {{.Issue.Title}} for {{.Repo}}: "{{excerpt .Comment.Body}}"`,
+
+	"CommitCommentEvent.created": `Commented on commit {{shortSHA .Comment.CommitID}} for {{.Repo}}: "{{excerpt .Comment.Body}}" at {{.Comment.Url}}`,
+	"CommitCommentEvent.edited":  `Edited a comment on commit {{shortSHA .Comment.CommitID}} for {{.Repo}}: "{{excerpt .Comment.Body}}" at {{.Comment.Url}}`,
+	"CommitCommentEvent.deleted": `Deleted a comment on commit {{shortSHA .Comment.CommitID}} for {{.Repo}}: "{{excerpt .Comment.Body}}"`,
+
+	"GollumEvent.created": "Created wiki page {{.Title}} for {{.Repo}} at {{.Url}}",
+	"GollumEvent.edited":  "Edited wiki page {{.Title}} for {{.Repo}} at {{.Url}}",
+
+	"MemberEvent.added":   "Added {{.Member.Login}} as a collaborator to {{.Repo}}",
+	"MemberEvent.removed": "Removed {{.Member.Login}} as a collaborator from {{.Repo}}",
+	"MemberEvent.edited":  "Changed the permission of {{.Member.Login}} on {{.Repo}}{{with .Changes.Permission}}{{if .To}} from {{or .From \"none\"}} to {{.To}}{{end}}{{end}}",
+
+	"PullRequestReviewEvent.approved":          "Approved pull request {{.PullRequest.Number}}. {{.PullRequest.Title}} for {{.Repo}} at {{.Review.Url}}",
+	"PullRequestReviewEvent.changes_requested": "Requested changes on pull request {{.PullRequest.Number}}. {{.PullRequest.Title}} for {{.Repo}} at {{.Review.Url}}",
+	"PullRequestReviewEvent.commented":         "Reviewed pull request {{.PullRequest.Number}}. {{.PullRequest.Title}} for {{.Repo}} with comments at {{.Review.Url}}",
+	"PullRequestReviewEvent.dismissed":         "Review of pull request {{.PullRequest.Number}}. {{.PullRequest.Title}} for {{.Repo}} is dismissed at {{.Review.Url}}",
+
+	"PullRequestReviewCommentEvent.created": `Commented on pull request {{.PullRequest.Number}}. {{.PullRequest.Title}} for {{.Repo}}: "{{excerpt .Comment.Body}}" at {{.Comment.Url}}`,
+	"PullRequestReviewCommentEvent.edited":  `Edited a comment on pull request {{.PullRequest.Number}}. {{.PullRequest.Title}} for {{.Repo}}: "{{excerpt .Comment.Body}}" at {{.Comment.Url}}`,
+	"PullRequestReviewCommentEvent.deleted": `Deleted a comment on pull request {{.PullRequest.Number}}. {{.PullRequest.Title}} for {{.Repo}}: "{{excerpt .Comment.Body}}"`,
+}
+
+// messageCatalog holds the parsed message templates, the defaults merged
+// with the translations of the locale and the overrides of the config file.
+type messageCatalog struct {
+	templates map[string]*template.Template
+}
+
+// messages is the catalogue used by the parse*Event functions.
+var messages = mustMessageCatalog(englishLocale, time.Local, nil)
+
+// messageKey returns the catalogue key of an event type and action.
+func messageKey(eventType, action string) string {
+	if action == "" {
+		return eventType
+	}
+	return eventType + "." + action
+}
+
+// newMessageCatalog parses the default messages, the translations of loc
+// and overrides. Translations and overrides replace defaults with the same
+// key, overrides can also add messages for other actions. Dates in messages
+// are shown in location.
+func newMessageCatalog(loc *locale, location *time.Location, overrides map[string]string) (*messageCatalog, error) {
+	mc := &messageCatalog{templates: make(map[string]*template.Template)}
+	funcs := templateFuncs(time.Now(), loc, location)
+
+	for _, source := range []map[string]string{defaultMessages, loc.Messages, overrides} {
+		keys := make([]string, 0, len(source))
+		for key := range source {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			tmpl, err := template.New(key).Funcs(funcs).Option("missingkey=error").Parse(source[key])
+			if err != nil {
+				return nil, fmt.Errorf("invalid message %s: %w", key, err)
+			}
+			mc.templates[key] = tmpl
+		}
+	}
+	return mc, nil
+}
+
+func mustMessageCatalog(loc *locale, location *time.Location, overrides map[string]string) *messageCatalog {
+	mc, err := newMessageCatalog(loc, location, overrides)
+	if err != nil {
+		panic(err)
+	}
+	return mc
+}
+
+// has reports whether the catalogue has a message for the event type and
+// action.
+func (mc *messageCatalog) has(eventType, action string) bool {
+	_, ok := mc.templates[messageKey(eventType, action)]
+	return ok
+}
+
+// render executes the message of the event type and action with data. It
+// returns an UnsupportedEventError when there is no such message.
+func (mc *messageCatalog) render(eventType, action string, data any) (string, error) {
+	tmpl, ok := mc.templates[messageKey(eventType, action)]
+	if !ok {
+		return "", &UnsupportedEventError{Type: eventType}
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("unable to render message %s: %w", tmpl.Name(), err)
+	}
+	return b.String(), nil
+}
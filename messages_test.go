@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// useMessages replaces the message catalogue for the duration of a test.
+func useMessages(t *testing.T, overrides map[string]string) {
+	t.Helper()
+	mc, err := newMessageCatalog(englishLocale, time.Local, overrides)
+	require.Nil(t, err)
+	previous := messages
+	messages = mc
+	t.Cleanup(func() { messages = previous })
+}
+
+func TestMessageCatalog(t *testing.T) {
+	t.Run("Successfully validates default messages parse", func(t *testing.T) {
+		mc, err := newMessageCatalog(englishLocale, time.Local, nil)
+		require.Nil(t, err)
+		require.Len(t, mc.templates, len(defaultMessages))
+	})
+
+	t.Run("Successfully validates override of a default message", func(t *testing.T) {
+		useMessages(t, map[string]string{"IssuesEvent.opened": "#{{.Issue.Number}} opened in {{.Repo}}"})
+		s, err := parseIssuesEvent(json.RawMessage(`{"action": "opened", "issue": {"number": 42, "title": "Crash"}}`), "devUser/awesome-project")
+		require.Nil(t, err)
+		require.Equal(t, "#42 opened in devUser/awesome-project", s)
+	})
+
+	t.Run("Successfully validates message for an action without default", func(t *testing.T) {
+		useMessages(t, map[string]string{"PullRequestEvent.transferred": "Transferred pull request {{.Number}} in {{.Repo}}"})
+		s, err := parsePullRequestEvent(json.RawMessage(`{"action": "transferred", "number": 42}`), "devUser/awesome-project")
+		require.Nil(t, err)
+		require.Equal(t, "Transferred pull request 42 in devUser/awesome-project", s)
+	})
+
+	t.Run("Successfully validates repository in ReleaseEvent message", func(t *testing.T) {
+		useMessages(t, map[string]string{"ReleaseEvent.published": "{{.Release.Name}} released in {{.Repo}}"})
+		s, err := parseReleaseEvent(json.RawMessage(`{"action": "published", "release": {"name": "v1.0.0"}}`), "devUser/awesome-project")
+		require.Nil(t, err)
+		require.Equal(t, "v1.0.0 released in devUser/awesome-project", s)
+	})
+
+	t.Run("Successfully validates dates in messages shown in the location", func(t *testing.T) {
+		location, err := time.LoadLocation("Asia/Kolkata")
+		require.Nil(t, err)
+		mc, err := newMessageCatalog(englishLocale, location, map[string]string{"ReleaseEvent.published": `{{date "15:04" .PublishedAt}}`})
+		require.Nil(t, err)
+		s, err := mc.render("ReleaseEvent", "published", struct{ PublishedAt time.Time }{time.Date(2024, 11, 28, 14, 5, 0, 0, time.UTC)})
+		require.Nil(t, err)
+		require.Equal(t, "19:35", s)
+	})
+
+	t.Run("Successfully validates error for invalid message", func(t *testing.T) {
+		_, err := newMessageCatalog(englishLocale, time.Local, map[string]string{"PushEvent": "Pushed {{.Size"})
+		require.ErrorContains(t, err, "invalid message PushEvent")
+	})
+
+	t.Run("Successfully validates error for message with unknown field", func(t *testing.T) {
+		useMessages(t, map[string]string{"PushEvent": "Pushed {{.Files}}"})
+		s, err := parsePushEvent(json.RawMessage(`{"size": 1}`), "devUser/awesome-project")
+		require.ErrorContains(t, err, "unable to render message PushEvent")
+		require.Empty(t, s)
+	})
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("Successfully validates messages from config file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		writeFile(t, path, `messages:
+  PushEvent: "{{.Repo}} +{{.Size}}"
+`)
+		cfg, err := loadConfig(path, true)
+		require.Nil(t, err)
+		require.Equal(t, map[string]string{"PushEvent": "{{.Repo}} +{{.Size}}"}, cfg.Messages)
+	})
+
+	t.Run("Successfully validates missing default config file", func(t *testing.T) {
+		cfg, err := loadConfig(filepath.Join(t.TempDir(), "config.yml"), false)
+		require.Nil(t, err)
+		require.Empty(t, cfg.Messages)
+	})
+
+	t.Run("Successfully validates error for missing explicit config file", func(t *testing.T) {
+		_, err := loadConfig(filepath.Join(t.TempDir(), "config.yml"), true)
+		require.ErrorContains(t, err, "unable to read config")
+	})
+}
+
+func TestRunConfig(t *testing.T) {
+	t.Run("Successfully validates messages from the default config file", func(t *testing.T) {
+		setupRun(t, newTestServer(t, []string{"[" + pushEventJSON + "]"}))
+		dir := t.TempDir()
+		t.Setenv("XDG_CONFIG_HOME", dir)
+		writeFile(t, filepath.Join(dir, "github-activity", "config.yml"), `messages:
+  PushEvent: "{{.Repo}} +{{.Size}}"
+`)
+		t.Cleanup(func() { messages = mustMessageCatalog(englishLocale, time.Local, nil) })
+
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--time", "iso", "--tz", "UTC", "devUser"}, &stdout, &stderr)
+		require.Nil(t, err)
+		require.Equal(t, "2024-11-28T14:05:00Z  devUser/awesome-project +2\n", stdout.String())
+	})
+}
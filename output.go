@@ -0,0 +1,277 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// outputSchemaVersion is bumped whenever a field of the JSON and NDJSON
+// output is renamed, removed or changes meaning. Version 2 made the
+// PushEvent payload size null when the API leaves it out.
+const outputSchemaVersion = 2
+
+// outputWriter renders activities in one of the --output formats.
+type outputWriter interface {
+	WriteActivity(a *Activity) error
+	// Close flushes the output, it is called even when fetching failed so
+	// partial output stays well formed.
+	Close() error
+}
+
+// outputMeta describes the run in the JSON document.
+type outputMeta struct {
+	User        string
+	GeneratedAt time.Time
+}
+
+type outputOptions struct {
+	Format string
+	// Columns selects and orders the CSV and TSV columns, all columns are
+	// written when empty.
+	Columns []string
+	// Template is the text of the user template used by the template format,
+	// TemplateName names it in error messages.
+	Template     string
+	TemplateName string
+	// Select is a --select pipeline printing values extracted from every
+	// event instead of the events, in the text, json or ndjson format.
+	Select string
+	// Time formats the timestamps of the text output: relative (default),
+	// absolute or iso, in Location, the local time zone by default.
+	Time     string
+	Location *time.Location
+	// Headers groups the text output under a header per day.
+	Headers bool
+	// Commits lists the commits of every push under it in the text output.
+	Commits bool
+	// Locale formats relative times, dates and plurals in the text output
+	// and templates, defaults to English.
+	Locale *locale
+	Meta   outputMeta
+}
+
+// timeLayouts are the layouts of the --time formats, relative times are
+// formatted by the locale.
+var timeLayouts = map[string]string{
+	"relative": "",
+	"absolute": "2006-01-02 15:04",
+	"iso":      time.RFC3339,
+}
+
+func newOutputWriter(w io.Writer, opts outputOptions) (outputWriter, error) {
+	if opts.Locale == nil {
+		opts.Locale = englishLocale
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+
+	if opts.Select != "" {
+		path, err := compileSelect(opts.Select)
+		if err != nil {
+			return nil, err
+		}
+		switch opts.Format {
+		case "text", "json", "ndjson":
+			return &selectWriter{w: w, path: path, format: opts.Format}, nil
+		}
+		return nil, &UsageError{Message: fmt.Sprintf("--select can't be used with --output %s, expected text, json or ndjson", opts.Format)}
+	}
+
+	switch opts.Format {
+	case "text":
+		if opts.Time == "" {
+			opts.Time = "relative"
+		}
+		if _, ok := timeLayouts[opts.Time]; !ok {
+			return nil, &UsageError{Message: fmt.Sprintf("unknown time format %q, expected relative, absolute or iso", opts.Time)}
+		}
+		return &textWriter{
+			w:        w,
+			time:     opts.Time,
+			location: opts.Location,
+			headers:  opts.Headers,
+			commits:  opts.Commits,
+			locale:   opts.Locale,
+			now:      opts.Meta.GeneratedAt.In(opts.Location),
+		}, nil
+	case "json":
+		return &jsonWriter{w: w, meta: opts.Meta, events: []jsonEvent{}}, nil
+	case "ndjson":
+		return &ndjsonWriter{enc: json.NewEncoder(w)}, nil
+	case "csv", "tsv":
+		comma := ','
+		if opts.Format == "tsv" {
+			comma = '\t'
+		}
+		cw, err := newCSVWriter(w, comma, opts.Columns)
+		if err != nil {
+			return nil, err
+		}
+		return cw, nil
+	case "template":
+		tmpl, err := parseTemplate(opts.TemplateName, opts.Template, opts.Meta.GeneratedAt, opts.Locale, opts.Location)
+		if err != nil {
+			return nil, err
+		}
+		return &templateWriter{w: w, tmpl: tmpl, data: templateData{User: opts.Meta.User, GeneratedAt: opts.Meta.GeneratedAt}}, nil
+	}
+	return nil, &UsageError{Message: fmt.Sprintf("unknown output format %q, expected text, json, ndjson, csv or tsv", opts.Format)}
+}
+
+// textWriter prints the message of every activity after its timestamp,
+// optionally under a header per day and followed by the commits of pushes.
+type textWriter struct {
+	w        io.Writer
+	time     string
+	location *time.Location
+	headers  bool
+	commits  bool
+	locale   *locale
+	now      time.Time
+	day      string
+}
+
+func (tw *textWriter) WriteActivity(a *Activity) error {
+	message := a.Message
+	if tw.commits {
+		message += commitLines(a)
+	}
+	if a.CreatedAt.IsZero() {
+		_, err := fmt.Fprintln(tw.w, message)
+		return err
+	}
+
+	t := a.CreatedAt.In(tw.location)
+	if tw.headers {
+		if day := tw.locale.dayHeader(t, tw.now); day != tw.day {
+			if tw.day != "" {
+				fmt.Fprintln(tw.w)
+			}
+			fmt.Fprintln(tw.w, day)
+			tw.day = day
+		}
+	}
+	prefix := tw.timestamp(t) + "  "
+	_, err := fmt.Fprintln(tw.w, prefix+indentLines(message, utf8.RuneCountInString(prefix)))
+	return err
+}
+
+// commitLines returns an indented line per commit of a push, with its short
+// SHA and the first line of its message.
+func commitLines(a *Activity) string {
+	push, ok := a.Payload.(*PushEvent)
+	if !ok {
+		return ""
+	}
+	var b strings.Builder
+	for _, c := range push.Commits {
+		subject, _, _ := strings.Cut(c.Message, "\n")
+		fmt.Fprintf(&b, "\n  %s %s", shortSHA(c.Sha), strings.TrimSpace(subject))
+	}
+	return b.String()
+}
+
+// indentLines indents every line of s but the first by n spaces, so the
+// lines of multi-line messages line up after the timestamp.
+func indentLines(s string, n int) string {
+	lines := strings.Split(s, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = strings.Repeat(" ", n) + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (tw *textWriter) timestamp(t time.Time) string {
+	if tw.time == "relative" {
+		return tw.locale.relativeTime(t, tw.now)
+	}
+	return t.Format(timeLayouts[tw.time])
+}
+
+func (tw *textWriter) Close() error {
+	return nil
+}
+
+// jsonEvent is the versioned representation of an activity in the JSON and
+// NDJSON output.
+type jsonEvent struct {
+	SchemaVersion int       `json:"schema_version,omitempty"`
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	Action        string    `json:"action,omitempty"`
+	Repo          string    `json:"repo"`
+	Actor         string    `json:"actor"`
+	CreatedAt     time.Time `json:"created_at"`
+	Message       string    `json:"message"`
+	Payload       any       `json:"payload,omitempty"`
+	Error         string    `json:"error,omitempty"`
+}
+
+func newJSONEvent(a *Activity) jsonEvent {
+	e := jsonEvent{
+		ID:        a.ID,
+		Type:      a.Type,
+		Action:    a.Action,
+		Repo:      a.Repo,
+		Actor:     a.Actor,
+		CreatedAt: a.CreatedAt,
+		Message:   a.Message,
+		Payload:   a.Payload,
+	}
+	if a.Err != nil {
+		e.Error = a.Err.Error()
+	}
+	return e
+}
+
+// jsonWriter buffers every activity and writes a single document on Close.
+type jsonWriter struct {
+	w      io.Writer
+	meta   outputMeta
+	events []jsonEvent
+}
+
+func (jw *jsonWriter) WriteActivity(a *Activity) error {
+	jw.events = append(jw.events, newJSONEvent(a))
+	return nil
+}
+
+func (jw *jsonWriter) Close() error {
+	enc := json.NewEncoder(jw.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		SchemaVersion int         `json:"schema_version"`
+		User          string      `json:"user"`
+		GeneratedAt   time.Time   `json:"generated_at"`
+		Count         int         `json:"count"`
+		Events        []jsonEvent `json:"events"`
+	}{
+		SchemaVersion: outputSchemaVersion,
+		User:          jw.meta.User,
+		GeneratedAt:   jw.meta.GeneratedAt,
+		Count:         len(jw.events),
+		Events:        jw.events,
+	})
+}
+
+// ndjsonWriter writes one JSON object per activity as soon as it is handled.
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (nw *ndjsonWriter) WriteActivity(a *Activity) error {
+	e := newJSONEvent(a)
+	e.SchemaVersion = outputSchemaVersion
+	return nw.enc.Encode(e)
+}
+
+func (nw *ndjsonWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const pushEventJSON = `{
+	"id": "2489651045",
+	"type": "PushEvent",
+	"actor": {"id": 112233, "login": "devUser"},
+	"repo": {"id": 3, "name": "devUser/awesome-project"},
+	"payload": {"size": 2, "ref": "refs/heads/main"},
+	"created_at": "2024-11-28T14:05:00Z"
+}`
+
+const pullRequestEventJSON = `{
+	"id": "2489651046",
+	"type": "PullRequestEvent",
+	"actor": {"id": 112233, "login": "devUser"},
+	"repo": {"id": 3, "name": "devUser/awesome-project"},
+	"payload": {"action": "opened", "number": 42, "pull_request": {"url": "https://api.github.com/repos/devUser/awesome-project/pulls/42", "html_url": "https://github.com/devUser/awesome-project/pull/42", "title": "Add feature X"}},
+	"created_at": "2024-11-28T15:00:00Z"
+}`
+
+func decodeRawEvent(t *testing.T, s string) RawEvent {
+	t.Helper()
+	var event RawEvent
+	require.Nil(t, json.Unmarshal([]byte(s), &event))
+	return event
+}
+
+func TestNewActivity(t *testing.T) {
+	t.Run("Successfully validates activity for PullRequestEvent", func(t *testing.T) {
+		a := newActivity(decodeRawEvent(t, pullRequestEventJSON))
+		require.Nil(t, a.Err)
+		require.Equal(t, "2489651046", a.ID)
+		require.Equal(t, "opened", a.Action)
+		require.Equal(t, "devUser", a.Actor)
+		require.Equal(t, time.Date(2024, 11, 28, 15, 0, 0, 0, time.UTC), a.CreatedAt)
+		require.Equal(t, "Pull request 42. Add feature X for devUser/awesome-project is opened at https://api.github.com/repos/devUser/awesome-project/pulls/42", a.Message)
+		pr, ok := a.Payload.(*PullRequestEvent)
+		require.True(t, ok)
+		require.Equal(t, 42, pr.Number)
+	})
+
+	t.Run("Successfully validates fallback for event that can't be parsed", func(t *testing.T) {
+		event := decodeRawEvent(t, `{"type": "ReleaseEvent", "repo": {"name": "devUser/awesome-project"}, "payload": {"action": "deleted"}}`)
+		a := newActivity(event)
+		require.EqualError(t, a.Err, "unable to parse")
+		require.Equal(t, "ReleaseEvent in devUser/awesome-project", a.Message)
+	})
+
+	t.Run("Successfully validates fallback for unsupported event type", func(t *testing.T) {
+		event := decodeRawEvent(t, `{"type": "SponsorshipEvent", "repo": {"name": "devUser/awesome-project"}, "payload": {"action": "created"}}`)
+		a := newActivity(event)
+		require.Nil(t, a.Err)
+		require.Equal(t, "SponsorshipEvent in devUser/awesome-project", a.Message)
+		require.Nil(t, a.Payload)
+	})
+}
+
+func TestOutputWriter(t *testing.T) {
+	activities := []*Activity{
+		newActivity(decodeRawEvent(t, pushEventJSON)),
+		newActivity(decodeRawEvent(t, pullRequestEventJSON)),
+	}
+	meta := outputMeta{User: "devUser", GeneratedAt: time.Date(2024, 11, 29, 0, 0, 0, 0, time.UTC)}
+
+	writeOpts := func(t *testing.T, opts outputOptions) string {
+		var buf bytes.Buffer
+		w, err := newOutputWriter(&buf, opts)
+		require.Nil(t, err)
+		for _, a := range activities {
+			require.Nil(t, w.WriteActivity(a))
+		}
+		require.Nil(t, w.Close())
+		return buf.String()
+	}
+	write := func(t *testing.T, format string) string {
+		return writeOpts(t, outputOptions{Format: format, Meta: meta})
+	}
+
+	t.Run("Successfully validates text output", func(t *testing.T) {
+		exp := "9 hours ago  Pushed 2 commits to main in devUser/awesome-project\n" +
+			"9 hours ago  Pull request 42. Add feature X for devUser/awesome-project is opened at https://api.github.com/repos/devUser/awesome-project/pulls/42\n"
+		require.Equal(t, exp, write(t, "text"))
+	})
+
+	t.Run("Successfully validates absolute time in a time zone", func(t *testing.T) {
+		paris, err := time.LoadLocation("Europe/Paris")
+		require.Nil(t, err)
+		s := writeOpts(t, outputOptions{Format: "text", Time: "absolute", Location: paris, Meta: meta})
+		require.Equal(t, "2024-11-28 15:05  Pushed 2 commits to main in devUser/awesome-project\n", s[:strings.Index(s, "\n")+1])
+	})
+
+	t.Run("Successfully validates day headers", func(t *testing.T) {
+		s := writeOpts(t, outputOptions{Format: "text", Time: "iso", Location: time.UTC, Headers: true, Meta: meta})
+		exp := "Yesterday\n" +
+			"2024-11-28T14:05:00Z  Pushed 2 commits to main in devUser/awesome-project\n" +
+			"2024-11-28T15:00:00Z  Pull request 42. Add feature X for devUser/awesome-project is opened at https://api.github.com/repos/devUser/awesome-project/pulls/42\n"
+		require.Equal(t, exp, s)
+	})
+
+	t.Run("Successfully validates multi-line messages are indented", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := newOutputWriter(&buf, outputOptions{Format: "text", Time: "iso", Location: time.UTC, Meta: meta})
+		require.Nil(t, err)
+		a := &Activity{CreatedAt: time.Date(2024, 11, 28, 14, 0, 0, 0, time.UTC), Message: "Edited wiki page Home\nCreated wiki page FAQ"}
+		require.Nil(t, w.WriteActivity(a))
+		require.Equal(t, "2024-11-28T14:00:00Z  Edited wiki page Home\n"+
+			"                      Created wiki page FAQ\n", buf.String())
+	})
+
+	t.Run("Successfully validates commits listed under pushes", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := newOutputWriter(&buf, outputOptions{Format: "text", Time: "iso", Location: time.UTC, Commits: true, Meta: meta})
+		require.Nil(t, err)
+		event := decodeRawEvent(t, `{
+			"type": "PushEvent",
+			"repo": {"name": "devUser/awesome-project"},
+			"payload": {"size": 2, "ref": "refs/heads/main", "commits": [
+				{"sha": "a1b2c3d4e5f67890", "message": "Fix crash on start\n\nThe config was read twice."},
+				{"sha": "1234abcd5678ef90", "message": "Update README"}
+			]},
+			"created_at": "2024-11-28T16:00:00Z"
+		}`)
+		require.Nil(t, w.WriteActivity(newActivity(event)))
+		require.Equal(t, "2024-11-28T16:00:00Z  Pushed 2 commits to main in devUser/awesome-project\n"+
+			"                        a1b2c3d Fix crash on start\n"+
+			"                        1234abc Update README\n", buf.String())
+	})
+
+	t.Run("Successfully validates error for unknown time format", func(t *testing.T) {
+		_, err := newOutputWriter(&bytes.Buffer{}, outputOptions{Format: "text", Time: "epoch", Meta: meta})
+		require.EqualError(t, err, `unknown time format "epoch", expected relative, absolute or iso`)
+		require.Equal(t, exitUsage, exitCode(err))
+	})
+
+	t.Run("Successfully validates json output", func(t *testing.T) {
+		var doc struct {
+			SchemaVersion int    `json:"schema_version"`
+			User          string `json:"user"`
+			Count         int    `json:"count"`
+			Events        []map[string]any
+		}
+		require.Nil(t, json.Unmarshal([]byte(write(t, "json")), &doc))
+		require.Equal(t, outputSchemaVersion, doc.SchemaVersion)
+		require.Equal(t, "devUser", doc.User)
+		require.Equal(t, 2, doc.Count)
+		require.Equal(t, "PushEvent", doc.Events[0]["type"])
+		require.Equal(t, map[string]any{"ref": "refs/heads/main", "before": "", "head": "", "size": float64(2), "distinct_size": nil, "commits": nil}, doc.Events[0]["payload"])
+		require.Equal(t, "opened", doc.Events[1]["action"])
+		require.Equal(t, "2024-11-28T15:00:00Z", doc.Events[1]["created_at"])
+	})
+
+	t.Run("Successfully validates ndjson output", func(t *testing.T) {
+		lines := bytes.Split(bytes.TrimSpace([]byte(write(t, "ndjson"))), []byte("\n"))
+		require.Len(t, lines, 2)
+		exp := `{"schema_version":2,"id":"2489651045","type":"PushEvent","repo":"devUser/awesome-project","actor":"devUser",` +
+			`"created_at":"2024-11-28T14:05:00Z","message":"Pushed 2 commits to main in devUser/awesome-project","payload":{"ref":"refs/heads/main","before":"","head":"","size":2,"distinct_size":null,"commits":null}}`
+		require.JSONEq(t, exp, string(lines[0]))
+	})
+
+	t.Run("Successfully validates null size of push without size", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := newOutputWriter(&buf, outputOptions{Format: "ndjson", Meta: meta})
+		require.Nil(t, err)
+		event := decodeRawEvent(t, `{"type": "PushEvent", "repo": {"name": "devUser/awesome-project"}, "payload": {"ref": "refs/heads/main", "head": "a1b2c3d"}}`)
+		require.Nil(t, w.WriteActivity(newActivity(event)))
+		var doc map[string]any
+		require.Nil(t, json.Unmarshal(buf.Bytes(), &doc))
+		require.Equal(t, float64(2), doc["schema_version"])
+		require.Nil(t, doc["payload"].(map[string]any)["size"])
+	})
+
+	t.Run("Successfully validates error for unknown output format", func(t *testing.T) {
+		_, err := newOutputWriter(&bytes.Buffer{}, outputOptions{Format: "yaml", Meta: meta})
+		require.EqualError(t, err, `unknown output format "yaml", expected text, json, ndjson, csv or tsv`)
+		require.Equal(t, exitUsage, exitCode(err))
+	})
+}
+
+func TestRunTimeZone(t *testing.T) {
+	t.Run("Successfully validates error for unknown time zone", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--tz", "Mars/Olympus", "devUser"}, &stdout, &stderr)
+		require.EqualError(t, err, `unknown time zone "Mars/Olympus"`)
+		require.Equal(t, exitUsage, exitCode(err))
+	})
+}
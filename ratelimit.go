@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// lowRateLimitRatio is the share of the quota under which a warning is
+	// printed.
+	lowRateLimitRatio = 0.1
+	// defaultSecondaryRateLimitWait is used for secondary rate limits
+	// without a Retry-After header, as advised by GitHub.
+	defaultSecondaryRateLimitWait = time.Minute
+	// maxRateLimitWaits bounds how often a single request waits for the rate
+	// limit before giving up.
+	maxRateLimitWaits = 3
+)
+
+type rateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
+	Reset     time.Time `json:"-"`
+}
+
+func (r *rateLimit) UnmarshalJSON(b []byte) error {
+	type limit rateLimit
+	var aux struct {
+		limit
+		Reset int64 `json:"reset"`
+	}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	*r = rateLimit(aux.limit)
+	r.Reset = time.Unix(aux.Reset, 0)
+	return nil
+}
+
+// parseRateLimit reads the X-RateLimit-* headers of a response.
+func parseRateLimit(header http.Header) (rateLimit, bool) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return rateLimit{}, false
+	}
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return rateLimit{}, false
+	}
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return rateLimit{}, false
+	}
+	used, _ := strconv.Atoi(header.Get("X-RateLimit-Used"))
+
+	return rateLimit{Limit: limit, Remaining: remaining, Used: used, Reset: time.Unix(reset, 0)}, true
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// rateLimitWait returns how long to wait before retrying a request that was
+// rejected with statusErr, and false if it wasn't rejected by a rate limit.
+func rateLimitWait(statusErr *StatusError, now time.Time) (time.Duration, bool) {
+	switch statusErr.Kind {
+	case statusRateLimited:
+		if statusErr.RetryAfter > 0 {
+			return statusErr.RetryAfter, true
+		}
+		if !statusErr.Reset.IsZero() {
+			// One extra second absorbs clock skew with GitHub.
+			return max(statusErr.Reset.Sub(now), 0) + time.Second, true
+		}
+		return defaultSecondaryRateLimitWait, true
+	case statusSecondaryRateLimited:
+		if statusErr.RetryAfter > 0 {
+			return statusErr.RetryAfter, true
+		}
+		return defaultSecondaryRateLimitWait, true
+	}
+	return 0, false
+}
+
+// trackRateLimit records the rate limit of a response and warns once when
+// the remaining budget runs low.
+func (c *client) trackRateLimit(header http.Header) {
+	limit, ok := parseRateLimit(header)
+	if !ok {
+		return
+	}
+	c.rateLimit = limit
+
+	if c.warnings == nil || c.warnedLowRateLimit || limit.Remaining == 0 {
+		return
+	}
+	if float64(limit.Remaining) <= float64(limit.Limit)*lowRateLimitRatio {
+		c.warnedLowRateLimit = true
+		fmt.Fprintf(c.warnings, "warning: %d of %d API requests remaining, resets at %s\n",
+			limit.Remaining, limit.Limit, limit.Reset.Local().Format(time.TimeOnly))
+	}
+}
+
+// rateLimits returns the current quota of every rate limit resource.
+func (c *client) rateLimits() (map[string]rateLimit, error) {
+	resp, err := c.get(c.baseURL + "/rate_limit")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Resources map[string]rateLimit `json:"resources"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, &DecodeError{What: "rate limit", Err: err}
+	}
+	return body.Resources, nil
+}
+
+// printRateLimits writes one line per rate limit resource, sorted by name.
+func printRateLimits(w io.Writer, limits map[string]rateLimit) {
+	names := make([]string, 0, len(limits))
+	for name := range limits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		limit := limits[name]
+		fmt.Fprintf(w, "%s: %d/%d remaining, resets at %s\n",
+			name, limit.Remaining, limit.Limit, limit.Reset.Local().Format(time.DateTime))
+	}
+}
+
+// runRateLimit implements the ratelimit subcommand.
+func runRateLimit(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("github-activity ratelimit", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: github-activity ratelimit [flags]")
+		fs.PrintDefaults()
+	}
+	cf := addClientFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return &UsageError{Message: "ratelimit takes no arguments"}
+	}
+
+	c, err := cf.newClient(stderr)
+	if err != nil {
+		return err
+	}
+
+	limits, err := c.rateLimits()
+	if err != nil {
+		return err
+	}
+	printRateLimits(stdout, limits)
+	return nil
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	t.Run("Successfully validates rate limit headers", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("X-RateLimit-Limit", "60")
+		header.Set("X-RateLimit-Remaining", "12")
+		header.Set("X-RateLimit-Used", "48")
+		header.Set("X-RateLimit-Reset", "1700000000")
+		limit, ok := parseRateLimit(header)
+		require.True(t, ok)
+		require.Equal(t, rateLimit{Limit: 60, Remaining: 12, Used: 48, Reset: time.Unix(1700000000, 0)}, limit)
+	})
+
+	t.Run("Successfully validates missing rate limit headers", func(t *testing.T) {
+		_, ok := parseRateLimit(http.Header{})
+		require.False(t, ok)
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Successfully validates Retry-After in seconds", func(t *testing.T) {
+		wait, ok := parseRetryAfter(http.Header{"Retry-After": {"30"}}, now)
+		require.True(t, ok)
+		require.Equal(t, 30*time.Second, wait)
+	})
+
+	t.Run("Successfully validates Retry-After as a date", func(t *testing.T) {
+		wait, ok := parseRetryAfter(http.Header{"Retry-After": {"Sat, 17 Oct 2026 12:02:00 GMT"}}, now)
+		require.True(t, ok)
+		require.Equal(t, 2*time.Minute, wait)
+	})
+
+	t.Run("Successfully validates missing Retry-After", func(t *testing.T) {
+		_, ok := parseRetryAfter(http.Header{}, now)
+		require.False(t, ok)
+	})
+}
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Successfully validates waiting for the primary rate limit reset", func(t *testing.T) {
+		wait, ok := rateLimitWait(&StatusError{Kind: statusRateLimited, Reset: now.Add(time.Minute)}, now)
+		require.True(t, ok)
+		require.Equal(t, time.Minute+time.Second, wait)
+	})
+
+	t.Run("Successfully validates waiting for Retry-After on secondary rate limit", func(t *testing.T) {
+		wait, ok := rateLimitWait(&StatusError{Kind: statusSecondaryRateLimited, RetryAfter: 30 * time.Second}, now)
+		require.True(t, ok)
+		require.Equal(t, 30*time.Second, wait)
+	})
+
+	t.Run("Successfully validates no wait for other errors", func(t *testing.T) {
+		_, ok := rateLimitWait(&StatusError{Kind: statusNotFound}, now)
+		require.False(t, ok)
+	})
+}
+
+func TestClientRateLimit(t *testing.T) {
+	t.Run("Successfully validates waiting on rate limit", func(t *testing.T) {
+		requests := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests == 1 {
+				w.Header().Set("Retry-After", "30")
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"message": "You have exceeded a secondary rate limit."}`)
+				return
+			}
+			fmt.Fprint(w, `[]`)
+		}))
+		defer srv.Close()
+
+		var warnings bytes.Buffer
+		var slept []time.Duration
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL, warnings: &warnings, waitOnRateLimit: true,
+			sleep: func(d time.Duration) { slept = append(slept, d) }}
+		resp, err := c.get(srv.URL)
+		require.Nil(t, err)
+		resp.Body.Close()
+		require.Equal(t, []time.Duration{30 * time.Second}, slept)
+		require.Equal(t, "warning: secondary rate limit exceeded, waiting 30s\n", warnings.String())
+	})
+
+	t.Run("Successfully validates rate limit waits don't count against the retry deadline", func(t *testing.T) {
+		requests := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests == 1 {
+				w.Header().Set("Retry-After", "2")
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"message": "You have exceeded a secondary rate limit."}`)
+				return
+			}
+			fmt.Fprint(w, `[]`)
+		}))
+		defer srv.Close()
+
+		policy := defaultRetryPolicy
+		policy.Deadline = 50 * time.Millisecond
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL, retry: policy, waitOnRateLimit: true,
+			sleep: func(time.Duration) { time.Sleep(2 * policy.Deadline) }}
+		resp, err := c.get(srv.URL)
+		require.Nil(t, err)
+		resp.Body.Close()
+		require.Equal(t, 2, requests)
+	})
+
+	t.Run("Successfully validates error without waiting on rate limit", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer srv.Close()
+
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL}
+		_, err := c.get(srv.URL)
+		require.EqualError(t, err, "secondary rate limit exceeded (HTTP 429), retry after 30s")
+		require.Equal(t, exitRateLimited, exitCode(err))
+	})
+
+	t.Run("Successfully validates warning when rate limit is low", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-RateLimit-Limit", "60")
+			w.Header().Set("X-RateLimit-Remaining", "5")
+			w.Header().Set("X-RateLimit-Reset", "1700000000")
+			fmt.Fprint(w, `[]`)
+		}))
+		defer srv.Close()
+
+		var warnings bytes.Buffer
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL, warnings: &warnings}
+		for range 2 {
+			resp, err := c.get(srv.URL)
+			require.Nil(t, err)
+			resp.Body.Close()
+		}
+		reset := time.Unix(1700000000, 0).Local().Format(time.TimeOnly)
+		require.Equal(t, "warning: 5 of 60 API requests remaining, resets at "+reset+"\n", warnings.String())
+		require.Equal(t, 5, c.rateLimit.Remaining)
+	})
+}
+
+func TestRunRateLimit(t *testing.T) {
+	t.Run("Successfully validates ratelimit subcommand", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/rate_limit", r.URL.Path)
+			fmt.Fprint(w, `{"resources": {
+				"search": {"limit": 10, "remaining": 10, "reset": 1700000000, "used": 0},
+				"core": {"limit": 60, "remaining": 59, "reset": 1700000000, "used": 1}
+			}}`)
+		}))
+		defer srv.Close()
+		setupRun(t, srv)
+
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"ratelimit"}, &stdout, &stderr)
+		require.Nil(t, err)
+		reset := time.Unix(1700000000, 0).Local().Format(time.DateTime)
+		require.Equal(t, "core: 59/60 remaining, resets at "+reset+"\n"+
+			"search: 10/10 remaining, resets at "+reset+"\n", stdout.String())
+	})
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+type retryPolicy struct {
+	// MaxAttempts is the number of attempts made before giving up, a value
+	// below 2 disables retries.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Deadline bounds the total time spent on a request, retries included.
+	Deadline time.Duration
+}
+
+var defaultRetryPolicy = retryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+	Deadline:    time.Minute,
+}
+
+// backoff returns the delay before the attempt following attempt failed
+// attempts: the exponential delay capped at MaxDelay, of which the upper
+// half is randomised.
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	delay := p.MaxDelay
+	if shift := attempt - 1; shift < 32 && p.BaseDelay<<shift < p.MaxDelay {
+		delay = p.BaseDelay << shift
+	}
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + rand.N(half+1)
+}
+
+// attempt records the outcome of a failed request attempt.
+type attempt struct {
+	Err     error
+	Elapsed time.Duration
+}
+
+// RetryError reports a request that still failed after being retried.
+type RetryError struct {
+	Attempts []attempt
+}
+
+func (e *RetryError) Error() string {
+	last := e.Attempts[len(e.Attempts)-1]
+	history := make([]string, len(e.Attempts))
+	for i, a := range e.Attempts {
+		history[i] = fmt.Sprintf("%s at %s", describeAttempt(a.Err), a.Elapsed.Round(time.Millisecond))
+	}
+	return fmt.Sprintf("%s (gave up after %d attempts: %s)", last.Err, len(e.Attempts), strings.Join(history, ", "))
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Attempts[len(e.Attempts)-1].Err
+}
+
+// describeAttempt returns a short description of an attempt error for the
+// attempt history.
+func describeAttempt(err error) string {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return fmt.Sprintf("HTTP %d", statusErr.StatusCode)
+	}
+	var networkErr *NetworkError
+	if errors.As(err, &networkErr) {
+		return networkErr.Err.Error()
+	}
+	return err.Error()
+}
+
+// isRetryable reports whether a failed GET is worth retrying: server errors,
+// connection resets and timeouts are transient, anything else is not.
+func isRetryable(method string, err error) bool {
+	if method != http.MethodGet && method != http.MethodHead {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Kind == statusServerError && statusErr.StatusCode != http.StatusNotImplemented
+	}
+
+	var networkErr *NetworkError
+	if !errors.As(err, &networkErr) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := retryPolicy{MaxAttempts: 10, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Deadline: time.Minute}
+
+	t.Run("Successfully validates exponential backoff with jitter", func(t *testing.T) {
+		for attempt, exp := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond} {
+			delay := policy.backoff(attempt)
+			require.GreaterOrEqual(t, delay, exp/2)
+			require.LessOrEqual(t, delay, exp)
+		}
+	})
+
+	t.Run("Successfully validates backoff is capped", func(t *testing.T) {
+		delay := policy.backoff(40)
+		require.GreaterOrEqual(t, delay, policy.MaxDelay/2)
+		require.LessOrEqual(t, delay, policy.MaxDelay)
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Run("Successfully validates server errors are retried", func(t *testing.T) {
+		require.True(t, isRetryable(http.MethodGet, &StatusError{StatusCode: 503, Kind: statusServerError}))
+	})
+
+	t.Run("Successfully validates connection resets are retried", func(t *testing.T) {
+		require.True(t, isRetryable(http.MethodGet, &NetworkError{URL: apiBaseURL, Err: syscall.ECONNRESET}))
+	})
+
+	t.Run("Successfully validates client errors are not retried", func(t *testing.T) {
+		require.False(t, isRetryable(http.MethodGet, &StatusError{StatusCode: 404, Kind: statusNotFound}))
+	})
+
+	t.Run("Successfully validates non idempotent requests are not retried", func(t *testing.T) {
+		require.False(t, isRetryable(http.MethodPost, &StatusError{StatusCode: 503, Kind: statusServerError}))
+	})
+}
+
+func TestClientRetry(t *testing.T) {
+	policy := retryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond, Deadline: time.Minute}
+
+	t.Run("Successfully validates retry after server error", func(t *testing.T) {
+		requests := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			fmt.Fprint(w, `[]`)
+		}))
+		defer srv.Close()
+
+		var slept int
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL, retry: policy, sleep: func(time.Duration) { slept++ }}
+		resp, err := c.get(srv.URL)
+		require.Nil(t, err)
+		resp.Body.Close()
+		require.Equal(t, 3, requests)
+		require.Equal(t, 2, slept)
+	})
+
+	t.Run("Successfully validates attempt history when giving up", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL, retry: policy, sleep: func(time.Duration) {}}
+		_, err := c.get(srv.URL)
+		var retryErr *RetryError
+		require.True(t, errors.As(err, &retryErr))
+		require.Len(t, retryErr.Attempts, 3)
+		require.Contains(t, err.Error(), "server error (HTTP 503) (gave up after 3 attempts: HTTP 503 at ")
+
+		var statusErr *StatusError
+		require.ErrorAs(t, err, &statusErr)
+		require.Equal(t, statusServerError, statusErr.Kind)
+	})
+
+	t.Run("Successfully validates giving up at the deadline", func(t *testing.T) {
+		requests := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		deadline := policy
+		deadline.Deadline = 0
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL, retry: deadline, sleep: func(time.Duration) {}}
+		_, err := c.get(srv.URL)
+		require.EqualError(t, err, "server error (HTTP 500)")
+		require.Equal(t, 1, requests)
+	})
+
+	t.Run("Successfully validates attempts time out at the deadline", func(t *testing.T) {
+		stall := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-stall:
+			case <-r.Context().Done():
+			}
+		}))
+		defer srv.Close()
+		defer close(stall)
+
+		deadline := policy
+		deadline.Deadline = 50 * time.Millisecond
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL, retry: deadline, sleep: func(time.Duration) {}}
+		start := time.Now()
+		_, err := c.get(srv.URL)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Less(t, time.Since(start), 5*time.Second)
+
+		var networkErr *NetworkError
+		require.ErrorAs(t, err, &networkErr)
+		require.True(t, isRetryable(http.MethodGet, err))
+	})
+
+	t.Run("Successfully validates no retry for not found", func(t *testing.T) {
+		requests := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		c := &client{httpClient: srv.Client(), baseURL: srv.URL, retry: policy, sleep: func(time.Duration) {}}
+		_, err := c.get(srv.URL)
+		require.EqualError(t, err, "not found (HTTP 404)")
+		require.Equal(t, 1, requests)
+	})
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// selectStep is a step of a --select path: a field, an array index or a
+// wildcard over every element.
+type selectStep struct {
+	field    string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// selectStage is a stage of a --select pipeline, either a path or one of
+// the functions length and keys.
+type selectStage struct {
+	steps []selectStep
+	fn    string
+}
+
+// selectPath is a compiled --select pipeline such as
+// payload.commits[*].message or .payload.pull_request | keys.
+type selectPath struct {
+	stages []selectStage
+}
+
+// selectFuncs are the functions a pipeline stage can apply.
+var selectFuncs = map[string]func(v any) (any, error){
+	"length": selectLength,
+	"keys":   selectKeys,
+}
+
+// compileSelect parses a --select pipeline, stages are separated by |.
+func compileSelect(src string) (*selectPath, error) {
+	sp := &selectPath{}
+	for _, part := range splitPipeline(src) {
+		stage, err := parseSelectStage(strings.TrimSpace(part))
+		if err != nil {
+			return nil, &UsageError{Message: fmt.Sprintf("invalid --select path %q: %s", src, err)}
+		}
+		sp.stages = append(sp.stages, stage)
+	}
+	return sp, nil
+}
+
+// closingBracket returns the offset of the ] closing the [ s starts with,
+// skipping quoted field names, or -1 when there is none.
+func closingBracket(s string) int {
+	quoted := false
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case !quoted && c == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// splitPipeline splits a pipeline on the | separating its stages, leaving
+// the | of bracketed and quoted field names alone.
+func splitPipeline(src string) []string {
+	var stages []string
+	depth, quoted, start := 0, false, 0
+	for i := 0; i < len(src); i++ {
+		switch c := src[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '[':
+			depth++
+		case c == ']' && depth > 0:
+			depth--
+		case c == '|' && depth == 0:
+			stages = append(stages, src[start:i])
+			start = i + 1
+		}
+	}
+	return append(stages, src[start:])
+}
+
+func parseSelectStage(s string) (selectStage, error) {
+	if _, ok := selectFuncs[s]; ok {
+		return selectStage{fn: s}, nil
+	}
+	if s == "" {
+		return selectStage{}, fmt.Errorf("empty path")
+	}
+	if s == "." {
+		return selectStage{}, nil
+	}
+
+	var steps []selectStep
+	i := 0
+	if s[0] != '.' && s[0] != '[' {
+		s = "." + s
+	}
+	for i < len(s) {
+		switch s[i] {
+		case '.':
+			i++
+			if i < len(s) && s[i] == '*' {
+				steps = append(steps, selectStep{wildcard: true})
+				i++
+				continue
+			}
+			start := i
+			for i < len(s) && s[i] != '.' && s[i] != '[' {
+				i++
+			}
+			if start == i {
+				return selectStage{}, fmt.Errorf("missing field name at offset %d", start)
+			}
+			steps = append(steps, selectStep{field: s[start:i]})
+		case '[':
+			end := closingBracket(s[i:])
+			if end < 0 {
+				return selectStage{}, fmt.Errorf("missing ] at offset %d", i)
+			}
+			inner := strings.TrimSpace(s[i+1 : i+end])
+			i += end + 1
+			switch {
+			case inner == "" || inner == "*":
+				steps = append(steps, selectStep{wildcard: true})
+			case strings.HasPrefix(inner, `"`):
+				field, err := strconv.Unquote(inner)
+				if err != nil {
+					return selectStage{}, fmt.Errorf("invalid field name %s", inner)
+				}
+				steps = append(steps, selectStep{field: field})
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return selectStage{}, fmt.Errorf("invalid index %q", inner)
+				}
+				steps = append(steps, selectStep{index: n, isIndex: true})
+			}
+		default:
+			return selectStage{}, fmt.Errorf("unexpected %q at offset %d", s[i], i)
+		}
+	}
+	return selectStage{steps: steps}, nil
+}
+
+// apply returns the values the pipeline extracts from v. Fields and indexes
+// missing from v give null, wildcards over anything but an array or object
+// give nothing.
+func (sp *selectPath) apply(v any) ([]any, error) {
+	values := []any{v}
+	for _, stage := range sp.stages {
+		var next []any
+		for _, value := range values {
+			if stage.fn != "" {
+				result, err := selectFuncs[stage.fn](value)
+				if err != nil {
+					return nil, err
+				}
+				next = append(next, result)
+				continue
+			}
+			next = append(next, applySteps(stage.steps, value)...)
+		}
+		values = next
+	}
+	return values, nil
+}
+
+func applySteps(steps []selectStep, v any) []any {
+	if len(steps) == 0 {
+		return []any{v}
+	}
+
+	step, rest := steps[0], steps[1:]
+	switch {
+	case step.wildcard:
+		var results []any
+		switch v := v.(type) {
+		case []any:
+			for _, item := range v {
+				results = append(results, applySteps(rest, item)...)
+			}
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				results = append(results, applySteps(rest, v[key])...)
+			}
+		}
+		return results
+	case step.isIndex:
+		items, _ := v.([]any)
+		i := step.index
+		if i < 0 {
+			i += len(items)
+		}
+		if i < 0 || i >= len(items) {
+			return applySteps(rest, nil)
+		}
+		return applySteps(rest, items[i])
+	}
+	object, _ := v.(map[string]any)
+	return applySteps(rest, object[step.field])
+}
+
+func selectLength(v any) (any, error) {
+	switch v := v.(type) {
+	case nil:
+		return 0, nil
+	case string:
+		return utf8.RuneCountInString(v), nil
+	case []any:
+		return len(v), nil
+	case map[string]any:
+		return len(v), nil
+	}
+	return nil, fmt.Errorf("unable to take the length of %s", describeValue(v))
+}
+
+func selectKeys(v any) (any, error) {
+	object, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unable to take the keys of %s", describeValue(v))
+	}
+	keys := make([]any, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].(string) < keys[j].(string) })
+	return keys, nil
+}
+
+// selectWriter prints the values a --select pipeline extracts from the raw
+// JSON of every event: strings as is and other values as JSON on a line
+// each for the text format, a JSON array for json, and a JSON value per
+// line for ndjson.
+type selectWriter struct {
+	w      io.Writer
+	path   *selectPath
+	format string
+	values []any
+}
+
+func (sw *selectWriter) WriteActivity(a *Activity) error {
+	dec := json.NewDecoder(bytes.NewReader(a.Raw))
+	dec.UseNumber()
+	var event any
+	if err := dec.Decode(&event); err != nil {
+		return &DecodeError{What: "event", Err: err}
+	}
+
+	values, err := sw.path.apply(event)
+	if err != nil {
+		return fmt.Errorf("unable to select from %s in %s: %w", a.Type, a.Repo, err)
+	}
+	if sw.format == "json" {
+		sw.values = append(sw.values, values...)
+		return nil
+	}
+
+	for _, v := range values {
+		if s, ok := v.(string); ok && sw.format == "text" {
+			if _, err := fmt.Fprintln(sw.w, s); err != nil {
+				return err
+			}
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(sw.w, string(b)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sw *selectWriter) Close() error {
+	if sw.format != "json" {
+		return nil
+	}
+	if sw.values == nil {
+		sw.values = []any{}
+	}
+	enc := json.NewEncoder(sw.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sw.values)
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const pushEventCommitsJSON = `{
+	"id": "2489651047",
+	"type": "PushEvent",
+	"actor": {"id": 112233, "login": "devUser"},
+	"repo": {"id": 3, "name": "devUser/awesome-project"},
+	"payload": {"size": 2, "commits": [
+		{"sha": "a1b2c3", "message": "Fix crash on start", "distinct": true},
+		{"sha": "d4e5f6", "message": "Update README", "distinct": false}
+	]},
+	"created_at": "2024-11-28T16:00:00Z"
+}`
+
+func TestSelectPath(t *testing.T) {
+	var event any
+	require.Nil(t, json.Unmarshal([]byte(pushEventCommitsJSON), &event))
+
+	apply := func(t *testing.T, src string) []any {
+		t.Helper()
+		sp, err := compileSelect(src)
+		require.Nil(t, err)
+		values, err := sp.apply(event)
+		require.Nil(t, err)
+		return values
+	}
+
+	t.Run("Successfully validates field access", func(t *testing.T) {
+		require.Equal(t, []any{"devUser"}, apply(t, ".actor.login"))
+		require.Equal(t, []any{"devUser/awesome-project"}, apply(t, `repo["name"]`))
+		require.Equal(t, []any{nil}, apply(t, "payload.pull_request.merged"))
+	})
+
+	t.Run("Successfully validates indexes and wildcards", func(t *testing.T) {
+		require.Equal(t, []any{"Fix crash on start", "Update README"}, apply(t, "payload.commits[*].message"))
+		require.Equal(t, []any{"d4e5f6"}, apply(t, "payload.commits[-1].sha"))
+		require.Equal(t, []any{nil}, apply(t, "payload.commits[5].sha"))
+		require.Equal(t, []any{float64(3), "devUser/awesome-project"}, apply(t, "repo.*"))
+	})
+
+	t.Run("Successfully validates pipes", func(t *testing.T) {
+		require.Equal(t, []any{2}, apply(t, "payload.commits | length"))
+		require.Equal(t, []any{[]any{"distinct", "message", "sha"}}, apply(t, "payload.commits[0] | keys"))
+		require.Equal(t, []any{true, false}, apply(t, "payload.commits | [*] | .distinct"))
+	})
+
+	t.Run("Successfully validates pipes inside quoted field names", func(t *testing.T) {
+		var event any
+		require.Nil(t, json.Unmarshal([]byte(`{"payload": {"a|b": "c|d"}}`), &event))
+		sp, err := compileSelect(`payload["a|b"] | length`)
+		require.Nil(t, err)
+		values, err := sp.apply(event)
+		require.Nil(t, err)
+		require.Equal(t, []any{3}, values)
+
+		require.Nil(t, json.Unmarshal([]byte(`{"payload": {"a]b": 1, "a\"]": 2}}`), &event))
+		for path, exp := range map[string]any{`payload["a]b"]`: float64(1), `payload["a\"]"]`: float64(2)} {
+			sp, err := compileSelect(path)
+			require.Nil(t, err)
+			values, err := sp.apply(event)
+			require.Nil(t, err)
+			require.Equal(t, []any{exp}, values)
+		}
+		require.Equal(t, []string{`payload["a\"|b"]`, ` keys`}, splitPipeline(`payload["a\"|b"]| keys`))
+	})
+
+	t.Run("Successfully validates error for invalid path", func(t *testing.T) {
+		_, err := compileSelect("payload.commits[x]")
+		require.EqualError(t, err, `invalid --select path "payload.commits[x]": invalid index "x"`)
+		require.Equal(t, exitUsage, exitCode(err))
+
+		_, err = compileSelect("payload |")
+		require.EqualError(t, err, `invalid --select path "payload |": empty path`)
+	})
+
+	t.Run("Successfully validates error for keys of a string", func(t *testing.T) {
+		sp, err := compileSelect("type | keys")
+		require.Nil(t, err)
+		_, err = sp.apply(event)
+		require.EqualError(t, err, "unable to take the keys of string")
+	})
+}
+
+func TestRunSelect(t *testing.T) {
+	pages := []string{"[" + pushEventCommitsJSON + "," + pullRequestEventJSON + "]"}
+
+	t.Run("Successfully validates values as lines", func(t *testing.T) {
+		setupRun(t, newTestServer(t, pages))
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--select", "payload.commits[*].message", "devUser"}, &stdout, &stderr)
+		require.Nil(t, err)
+		require.Equal(t, "Fix crash on start\nUpdate README\n", stdout.String())
+	})
+
+	t.Run("Successfully validates values as JSON", func(t *testing.T) {
+		setupRun(t, newTestServer(t, pages))
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--select", "payload.size", "--output", "json", "devUser"}, &stdout, &stderr)
+		require.Nil(t, err)
+		require.JSONEq(t, `[2, null]`, stdout.String())
+	})
+
+	t.Run("Successfully validates values as NDJSON", func(t *testing.T) {
+		setupRun(t, newTestServer(t, pages))
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--select", "payload.commits[0]", "--output", "ndjson", "--type", "PushEvent", "devUser"}, &stdout, &stderr)
+		require.Nil(t, err)
+		require.Equal(t, `{"distinct":true,"message":"Fix crash on start","sha":"a1b2c3"}`+"\n", stdout.String())
+	})
+
+	t.Run("Successfully validates error for select with csv", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--select", "id", "--output", "csv", "devUser"}, &stdout, &stderr)
+		require.EqualError(t, err, "--select can't be used with --output csv, expected text, json or ndjson")
+	})
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxErrorBodySize bounds how much of an error response is read.
+const maxErrorBodySize = 1 << 20
+
+type statusKind int
+
+const (
+	statusUnexpected statusKind = iota
+	statusRedirect
+	statusBadCredentials
+	statusForbidden
+	statusNotFound
+	statusValidationFailed
+	statusRateLimited
+	statusSecondaryRateLimited
+	statusServerError
+)
+
+func (k statusKind) String() string {
+	switch k {
+	case statusRedirect:
+		return "unexpected redirect"
+	case statusBadCredentials:
+		return "bad credentials"
+	case statusForbidden:
+		return "forbidden"
+	case statusNotFound:
+		return "not found"
+	case statusValidationFailed:
+		return "validation failed"
+	case statusRateLimited:
+		return "rate limit exceeded"
+	case statusSecondaryRateLimited:
+		return "secondary rate limit exceeded"
+	case statusServerError:
+		return "server error"
+	}
+	return "unexpected status"
+}
+
+// githubErrorDetail is an entry of the errors array of a GitHub error
+// response, which is either an object or a plain string.
+type githubErrorDetail struct {
+	Resource string `json:"resource"`
+	Field    string `json:"field"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+func (d *githubErrorDetail) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		d.Message = s
+		return nil
+	}
+
+	type detail githubErrorDetail
+	return json.Unmarshal(b, (*detail)(d))
+}
+
+func (d githubErrorDetail) String() string {
+	if d.Message != "" {
+		return d.Message
+	}
+	return strings.TrimSpace(strings.Join([]string{d.Resource, d.Field, d.Code}, " "))
+}
+
+// StatusError reports a response with an unsuccessful HTTP status code,
+// along with the details GitHub gave in the response body.
+type StatusError struct {
+	StatusCode       int
+	Kind             statusKind
+	Message          string
+	DocumentationURL string
+	Errors           []githubErrorDetail
+	Location         string
+	// Reset is when the primary rate limit resets and RetryAfter how long
+	// GitHub asked to wait, when known.
+	Reset      time.Time
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (HTTP %d)", e.Kind, e.StatusCode)
+	if e.Location != "" {
+		fmt.Fprintf(&b, " to %s", e.Location)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	for _, detail := range e.Errors {
+		fmt.Fprintf(&b, "; %s", detail)
+	}
+	if e.DocumentationURL != "" {
+		fmt.Fprintf(&b, " (see %s)", e.DocumentationURL)
+	}
+	if e.RetryAfter > 0 {
+		fmt.Fprintf(&b, ", retry after %s", e.RetryAfter)
+	} else if !e.Reset.IsZero() && e.Kind == statusRateLimited {
+		fmt.Fprintf(&b, ", resets at %s", e.Reset.Local().Format(time.TimeOnly))
+	}
+	return b.String()
+}
+
+// checkResponse returns nil for successful and not modified responses, and
+// a StatusError classifying any other response.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 || resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+
+	statusErr := &StatusError{StatusCode: resp.StatusCode}
+
+	var body struct {
+		Message          string              `json:"message"`
+		DocumentationURL string              `json:"documentation_url"`
+		Errors           []githubErrorDetail `json:"errors"`
+	}
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err := json.Unmarshal(b, &body); err == nil {
+		statusErr.Message = body.Message
+		statusErr.DocumentationURL = body.DocumentationURL
+		statusErr.Errors = body.Errors
+	}
+
+	statusErr.Kind = classifyStatus(resp, statusErr.Message)
+	switch statusErr.Kind {
+	case statusRedirect:
+		statusErr.Location = resp.Header.Get("Location")
+	case statusRateLimited, statusSecondaryRateLimited:
+		if limit, ok := parseRateLimit(resp.Header); ok && limit.Remaining == 0 {
+			statusErr.Reset = limit.Reset
+		}
+		statusErr.RetryAfter, _ = parseRetryAfter(resp.Header, time.Now())
+	}
+	return statusErr
+}
+
+func classifyStatus(resp *http.Response, message string) statusKind {
+	code := resp.StatusCode
+	lower := strings.ToLower(message)
+
+	switch {
+	case code >= 300 && code < 400:
+		return statusRedirect
+	case code == http.StatusUnauthorized:
+		return statusBadCredentials
+	case code == http.StatusForbidden || code == http.StatusTooManyRequests:
+		if strings.Contains(lower, "secondary rate limit") || strings.Contains(lower, "abuse") || resp.Header.Get("Retry-After") != "" {
+			return statusSecondaryRateLimited
+		}
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" || code == http.StatusTooManyRequests {
+			return statusRateLimited
+		}
+		return statusForbidden
+	case code == http.StatusNotFound:
+		return statusNotFound
+	case code == http.StatusUnprocessableEntity:
+		return statusValidationFailed
+	case code >= 500:
+		return statusServerError
+	}
+	return statusUnexpected
+}
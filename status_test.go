@@ -0,0 +1,80 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newResponse(statusCode int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: statusCode, Header: header, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestCheckResponse(t *testing.T) {
+	t.Run("Successfully validates success status code", func(t *testing.T) {
+		require.Nil(t, checkResponse(newResponse(200, nil, "[]")))
+	})
+
+	t.Run("Successfully validates not modified status code", func(t *testing.T) {
+		require.Nil(t, checkResponse(newResponse(304, nil, "")))
+	})
+
+	t.Run("Successfully validates forbidden status code", func(t *testing.T) {
+		err := checkResponse(newResponse(403, nil, ""))
+		require.EqualError(t, err, "forbidden (HTTP 403)")
+	})
+
+	t.Run("Successfully validates not found status code", func(t *testing.T) {
+		body := `{"message": "Not Found", "documentation_url": "https://docs.github.com/rest/activity/events", "status": "404"}`
+		err := checkResponse(newResponse(404, nil, body))
+		require.EqualError(t, err, "not found (HTTP 404): Not Found (see https://docs.github.com/rest/activity/events)")
+		require.Equal(t, exitNotFound, exitCode(err))
+	})
+
+	t.Run("Successfully validates service unavailable status code", func(t *testing.T) {
+		err := checkResponse(newResponse(503, nil, "<html>unavailable</html>"))
+		require.EqualError(t, err, "server error (HTTP 503)")
+	})
+
+	t.Run("Successfully validates bad credentials", func(t *testing.T) {
+		body := `{"message": "Bad credentials", "documentation_url": "https://docs.github.com/rest"}`
+		err := checkResponse(newResponse(401, nil, body))
+		require.EqualError(t, err, "bad credentials (HTTP 401): Bad credentials (see https://docs.github.com/rest)")
+	})
+
+	t.Run("Successfully validates primary rate limit", func(t *testing.T) {
+		header := http.Header{"X-Ratelimit-Remaining": {"0"}}
+		body := `{"message": "API rate limit exceeded for 127.0.0.1."}`
+		err := checkResponse(newResponse(403, header, body))
+		require.EqualError(t, err, "rate limit exceeded (HTTP 403): API rate limit exceeded for 127.0.0.1.")
+		require.Equal(t, exitRateLimited, exitCode(err))
+	})
+
+	t.Run("Successfully validates secondary rate limit", func(t *testing.T) {
+		header := http.Header{"Retry-After": {"60"}}
+		body := `{"message": "You have exceeded a secondary rate limit."}`
+		err := checkResponse(newResponse(429, header, body))
+		var statusErr *StatusError
+		require.ErrorAs(t, err, &statusErr)
+		require.Equal(t, statusSecondaryRateLimited, statusErr.Kind)
+		require.Equal(t, exitRateLimited, exitCode(err))
+	})
+
+	t.Run("Successfully validates validation failed with error details", func(t *testing.T) {
+		body := `{"message": "Validation Failed", "errors": [{"resource": "Event", "field": "page", "code": "invalid"}, "page is too large"]}`
+		err := checkResponse(newResponse(422, nil, body))
+		require.EqualError(t, err, "validation failed (HTTP 422): Validation Failed; Event page invalid; page is too large")
+	})
+
+	t.Run("Successfully validates unexpected redirect", func(t *testing.T) {
+		header := http.Header{"Location": {"https://api.github.com/user/1/events"}}
+		err := checkResponse(newResponse(301, header, ""))
+		require.EqualError(t, err, "unexpected redirect (HTTP 301) to https://api.github.com/user/1/events")
+	})
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// templateData is the data user templates are executed with.
+type templateData struct {
+	User        string
+	GeneratedAt time.Time
+	Events      []*Activity
+}
+
+// activityGroup is a group of activities sharing the same key, as returned
+// by the groupBy template function.
+type activityGroup struct {
+	Key    string
+	Events []*Activity
+}
+
+// groupKeys are the fields the groupBy template function can group by,
+// dates are taken in location.
+var groupKeys = map[string]func(a *Activity, location *time.Location) string{
+	"type":   func(a *Activity, _ *time.Location) string { return a.Type },
+	"action": func(a *Activity, _ *time.Location) string { return a.Action },
+	"repo":   func(a *Activity, _ *time.Location) string { return a.Repo },
+	"actor":  func(a *Activity, _ *time.Location) string { return a.Actor },
+	"date": func(a *Activity, location *time.Location) string {
+		return a.CreatedAt.In(location).Format(time.DateOnly)
+	},
+}
+
+// groupActivities groups activities by field, keeping groups in the order
+// their first activity appears.
+func groupActivities(location *time.Location, field string, activities []*Activity) ([]activityGroup, error) {
+	key, ok := groupKeys[field]
+	if !ok {
+		return nil, fmt.Errorf("unable to group by %q, expected type, action, repo, actor or date", field)
+	}
+
+	var groups []activityGroup
+	index := make(map[string]int)
+	for _, a := range activities {
+		k := key(a, location)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, activityGroup{Key: k})
+		}
+		groups[i].Events = append(groups[i].Events, a)
+	}
+	return groups, nil
+}
+
+// truncate shortens s to at most n characters, ending with an ellipsis when
+// it was cut.
+func truncate(n int, s string) string {
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	if n == 1 {
+		return "…"
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// excerptLength is the length of the comment excerpts of messages.
+const excerptLength = 60
+
+// excerpt collapses s to a single line and shortens it to excerptLength
+// characters.
+func excerpt(s string) string {
+	return truncate(excerptLength, strings.Join(strings.Fields(s), " "))
+}
+
+// shortSHA abbreviates a commit SHA to 7 characters, as GitHub does.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// githubURL joins parts into a github.com URL, such as
+// githubURL "devUser/awesome-project" "pull" 42.
+func githubURL(parts ...any) string {
+	segments := make([]string, len(parts))
+	for i, part := range parts {
+		segments[i] = strings.Trim(fmt.Sprint(part), "/")
+	}
+	u := url.URL{Scheme: "https", Host: githubHost, Path: "/" + strings.Join(segments, "/")}
+	return u.String()
+}
+
+// templateFuncs returns the functions of user templates and messages, ago,
+// localDate and plural follow the rules of loc and dates are shown in
+// location.
+func templateFuncs(now time.Time, loc *locale, location *time.Location) template.FuncMap {
+	return template.FuncMap{
+		"ago":       func(t time.Time) string { return loc.relativeTime(t, now) },
+		"date":      func(layout string, t time.Time) string { return t.In(location).Format(layout) },
+		"localDate": func(t time.Time) string { return loc.formatDate(t.In(location)) },
+		"plural":    loc.pluralForm,
+		"truncate":  truncate,
+		"excerpt":   excerpt,
+		"shortSHA":  shortSHA,
+		"githubURL": githubURL,
+		"groupBy": func(field string, activities []*Activity) ([]activityGroup, error) {
+			return groupActivities(location, field, activities)
+		},
+		"join":  strings.Join,
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+	}
+}
+
+// parseTemplate parses a user template, reporting syntax errors as usage
+// errors.
+func parseTemplate(name, text string, now time.Time, loc *locale, location *time.Location) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs(now, loc, location)).Parse(text)
+	if err != nil {
+		return nil, &UsageError{Message: err.Error()}
+	}
+	return tmpl, nil
+}
+
+// templateWriter buffers every activity and executes the template once with
+// all of them on Close.
+type templateWriter struct {
+	w    io.Writer
+	tmpl *template.Template
+	data templateData
+}
+
+func (tw *templateWriter) WriteActivity(a *Activity) error {
+	tw.data.Events = append(tw.data.Events, a)
+	return nil
+}
+
+func (tw *templateWriter) Close() error {
+	return tw.tmpl.Execute(tw.w, tw.data)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncate(t *testing.T) {
+	t.Run("Successfully validates truncated string", func(t *testing.T) {
+		require.Equal(t, "Add feat…", truncate(9, "Add feature X"))
+	})
+
+	t.Run("Successfully validates short string is unchanged", func(t *testing.T) {
+		require.Equal(t, "Add feature X", truncate(20, "Add feature X"))
+	})
+}
+
+func TestExcerpt(t *testing.T) {
+	t.Run("Successfully validates single line excerpt", func(t *testing.T) {
+		require.Equal(t, "Looks good to me. Merging.", excerpt("Looks good\tto me.\r\n\r\nMerging.  "))
+	})
+
+	t.Run("Successfully validates long excerpt is truncated", func(t *testing.T) {
+		s := excerpt(strings.Repeat("word ", 20))
+		require.Equal(t, excerptLength, utf8.RuneCountInString(s))
+		require.True(t, strings.HasSuffix(s, "…"))
+	})
+}
+
+func TestShortSHA(t *testing.T) {
+	t.Run("Successfully validates abbreviated SHA", func(t *testing.T) {
+		require.Equal(t, "a1b2c3d", shortSHA("a1b2c3d4e5f67890abcdef1234567890abcdef12"))
+		require.Equal(t, "a1b2", shortSHA("a1b2"))
+	})
+}
+
+func TestGithubURL(t *testing.T) {
+	t.Run("Successfully validates URL from parts", func(t *testing.T) {
+		require.Equal(t, "https://github.com/devUser/awesome-project/pull/42", githubURL("devUser/awesome-project", "pull", 42))
+	})
+}
+
+func TestTemplateWriter(t *testing.T) {
+	activities := []*Activity{
+		newActivity(decodeRawEvent(t, pushEventJSON)),
+		newActivity(decodeRawEvent(t, pullRequestEventJSON)),
+	}
+	meta := outputMeta{User: "devUser", GeneratedAt: time.Date(2024, 11, 28, 18, 0, 0, 0, time.UTC)}
+
+	write := func(t *testing.T, text string) (string, error) {
+		var buf bytes.Buffer
+		w, err := newOutputWriter(&buf, outputOptions{Format: "template", Template: text, TemplateName: "format", Meta: meta})
+		if err != nil {
+			return "", err
+		}
+		for _, a := range activities {
+			require.Nil(t, w.WriteActivity(a))
+		}
+		err = w.Close()
+		return buf.String(), err
+	}
+
+	t.Run("Successfully validates template with typed payload", func(t *testing.T) {
+		s, err := write(t, `{{range .Events}}{{.Type}} {{.CreatedAt | ago}}{{if eq .Type "PushEvent"}}: {{.Payload.Size}} {{plural .Payload.Size "commit" "commits"}}{{end}}
+{{end}}`)
+		require.Nil(t, err)
+		require.Equal(t, "PushEvent 3 hours ago: 2 commits\nPullRequestEvent 3 hours ago\n", s)
+	})
+
+	t.Run("Successfully validates groupBy, truncate and githubURL", func(t *testing.T) {
+		s, err := write(t, `{{range groupBy "repo" .Events}}{{.Key}} ({{len .Events}})
+{{range .Events}}- {{truncate 12 .Message}} {{githubURL .Repo}}
+{{end}}{{end}}`)
+		require.Nil(t, err)
+		require.Equal(t, "devUser/awesome-project (2)\n"+
+			"- Pushed 2 co… https://github.com/devUser/awesome-project\n"+
+			"- Pull reques… https://github.com/devUser/awesome-project\n", s)
+	})
+
+	t.Run("Successfully validates dates in the time zone", func(t *testing.T) {
+		tokyo, err := time.LoadLocation("Asia/Tokyo")
+		require.Nil(t, err)
+		var buf bytes.Buffer
+		w, err := newOutputWriter(&buf, outputOptions{Format: "template", Location: tokyo, Meta: meta,
+			Template: `{{range groupBy "date" .Events}}{{.Key}}:{{range .Events}} {{date "15:04" .CreatedAt}}{{end}}
+{{end}}`})
+		require.Nil(t, err)
+		for _, a := range activities {
+			require.Nil(t, w.WriteActivity(a))
+		}
+		require.Nil(t, w.Close())
+		require.Equal(t, "2024-11-28: 23:05\n2024-11-29: 00:00\n", buf.String())
+	})
+
+	t.Run("Successfully validates error for invalid template", func(t *testing.T) {
+		_, err := write(t, `{{range .Events}}`)
+		require.Equal(t, exitUsage, exitCode(err))
+	})
+
+	t.Run("Successfully validates error for unknown group", func(t *testing.T) {
+		_, err := write(t, `{{range groupBy "stars" .Events}}{{end}}`)
+		require.ErrorContains(t, err, `unable to group by "stars"`)
+	})
+}
+
+func TestRunTemplate(t *testing.T) {
+	t.Run("Successfully validates template file", func(t *testing.T) {
+		setupRun(t, newTestServer(t, []string{"[" + pushEventJSON + "]"}))
+		path := filepath.Join(t.TempDir(), "events.tmpl")
+		writeFile(t, path, `{{.User}}:{{range .Events}} {{.Repo}}{{end}}`)
+
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--template", path, "devUser"}, &stdout, &stderr)
+		require.Nil(t, err)
+		require.Equal(t, "devUser: devUser/awesome-project", stdout.String())
+	})
+
+	t.Run("Successfully validates error for template with output", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		err := run([]string{"--format", "{{.User}}", "--output", "json", "devUser"}, &stdout, &stderr)
+		require.EqualError(t, err, "--template and --format can't be used with --output")
+	})
+}